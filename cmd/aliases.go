@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// findAlias returns the alias matching 'name' (ignoring case, spaces and punctuation)
+// and the places it stands for.
+func (r *Resolver) findAlias(name string) (string, []string, bool) {
+	cleaned := cleanWord(name)
+	for alias, places := range r.aliases {
+		if cleanWord(alias) == cleaned {
+			return alias, places, true
+		}
+	}
+	return "", nil, false
+}
+
+// expandAlias resolves every place of an alias.
+//
+// Parameters:
+//   - alias: The name of the alias.
+//
+// Returns:
+//   - []locationInfo: One location per place of the alias, in the configured order.
+//   - error: any error message if a place can't be resolved.
+func (r *Resolver) expandAlias(alias string) ([]locationInfo, error) {
+	_, places, ok := r.findAlias(alias)
+	if !ok {
+		return nil, fmt.Errorf("Alias '%s' not found!", alias)
+	}
+	locations := make([]locationInfo, 0, len(places))
+	for _, place := range places {
+		location, err := r.resolveBuiltinPlace(place)
+		if err != nil {
+			return nil, err
+		}
+		location.place = place
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// aliasLocations returns the places of an alias with their time, difference to 'home', day,
+// status, holidays and, if 'daylight' is set, whether it's day or night, all at the instant 'now'.
+func (r *Resolver) aliasLocations(alias string, home *time.Location, daylight bool, now time.Time) ([]locationInfo, error) {
+	locations, err := r.expandAlias(alias)
+	if err != nil {
+		return nil, err
+	}
+	for i := range locations {
+		loc, err := loadLocation(locations[i].timezone)
+		if err != nil {
+			return nil, err
+		}
+		locations[i].formattedTime = now.In(loc).Format(r.timeLayout)
+		locations[i].difference = formatOffsetDifference(offsetDifference(now.In(home), now.In(loc)))
+		locations[i].day = describeDay(now.In(home), now.In(loc))
+		if locations[i].status, err = r.locationStatus(locations[i], now); err != nil {
+			return nil, err
+		}
+		locations[i].holiday = r.holidayOn(locations[i], now.In(loc))
+		if daylight {
+			if locations[i].daylight, err = daylightAt(locations[i], now); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return locations, nil
+}
+
+// showAlias prints a table with one row per place of the alias at the instant 'now', with a
+// Daylight column if 'daylight' is set.
+func (r *Resolver) showAlias(alias string, home *time.Location, daylight bool, now time.Time) {
+	locations, err := r.aliasLocations(alias, home, daylight, now)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	renderLocationsTable(fmt.Sprintf("Timezones for %v:", alias), locations)
+}
+
+// SetAlias stores an alias for one or more places in the configuration file.
+// Each place can be a city, a country or a timezone.
+func SetAlias(alias string, places []string) error {
+	if cleanWord(alias) == "" {
+		return fmt.Errorf("Invalid alias name '%s'", alias)
+	}
+	return updateConfigFile([]string{"aliases", alias}, places)
+}
+
+// RemoveAlias removes an alias from the configuration file.
+func RemoveAlias(r *Resolver, alias string) error {
+	name, _, ok := r.findAlias(alias)
+	if !ok {
+		return fmt.Errorf("Alias '%s' not found!", alias)
+	}
+	return updateConfigFile([]string{"aliases", name}, nil)
+}
+
+// ListAliases prints every alias with the places it stands for.
+func ListAliases() {
+	for _, alias := range sortedKeys(config.Aliases) {
+		fmt.Printf("%s = %s\n", alias, strings.Join(config.Aliases[alias], ", "))
+	}
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// withAliases sets the aliases of the configuration for the duration of a test.
+func withAliases(t *testing.T, aliases map[string][]string) {
+	t.Helper()
+	previous := config
+	config.Aliases = aliases
+	t.Cleanup(func() { config = previous })
+}
+
+func TestExpandAlias(t *testing.T) {
+	withAliases(t, map[string][]string{
+		"apac":          {"Tokyo", "Singapore", "Sydney"},
+		"berlin-office": {"Europe/Berlin"},
+	})
+
+	locations, err := DefaultResolver().expandAlias("APAC")
+	if err != nil {
+		t.Fatalf("expandAlias(APAC) returned error %v", err)
+	}
+	var got []string
+	for _, location := range locations {
+		got = append(got, location.timezone)
+	}
+	want := []string{"Asia/Tokyo", "Asia/Singapore", "Australia/Sydney"}
+	if !EqualSlices(got, want) {
+		t.Fatalf("`expandAlias(APAC)=%v`, want %v", got, want)
+	}
+
+	location, err := DefaultResolver().resolvePlace("Berlin Office")
+	if err != nil || location.timezone != "Europe/Berlin" {
+		t.Fatalf("`resolvePlace(Berlin Office)=%v, %v`, want Europe/Berlin", location.timezone, err)
+	}
+	if _, err := DefaultResolver().resolvePlace("apac"); err == nil {
+		t.Fatalf("`resolvePlace(apac)` returned no error for a group alias")
+	}
+}
+
+func TestGetMatchingLocationAlias(t *testing.T) {
+	withAliases(t, map[string][]string{"berlin": {"Asia/Tokyo"}, "mom": {"Kathmandu"}})
+
+	tests := []struct {
+		given string
+		want  []string
+	}{
+		// the alias wins over the built-in city with the same name
+		{given: "Berlin", want: []string{"berlin"}},
+		{given: "mom", want: []string{"mom"}},
+	}
+	for _, test := range tests {
+		got, err := DefaultResolver().getMatchingLocation(test.given, "")
+		if err != nil || !EqualSlices(got, test.want) {
+			t.Fatalf("`getMatchingLocation(%v,\"\")=%v, %v`, want %v", test.given, got, err, test.want)
+		}
+	}
+}
+
+func TestSetAndRemoveAlias(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("KTZ_CONFIG", path)
+	withAliases(t, map[string][]string{})
+
+	if err := SetAlias("apac", []string{"Tokyo", "Singapore"}); err != nil {
+		t.Fatalf("SetAlias(apac) returned error %v", err)
+	}
+	if err := SetAlias("nowhere", []string{"Xyzzyq"}); err == nil {
+		t.Fatalf("SetAlias(nowhere) returned no error for an unknown place")
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), `apac = ["Tokyo", "Singapore"]`) {
+		t.Fatalf("config file = %q, want the apac alias", content)
+	}
+	if err := RemoveAlias(DefaultResolver(), "apac"); err != nil {
+		t.Fatalf("RemoveAlias(apac) returned error %v", err)
+	}
+	if _, _, ok := DefaultResolver().findAlias("apac"); ok {
+		t.Fatalf("alias apac still found after RemoveAlias")
+	}
+}
+
+func TestAliasLocations(t *testing.T) {
+	withAliases(t, map[string][]string{"apac": {"Tokyo"}})
+	config.Clock = 24
+
+	// a second before midnight of Marine Day in Tokyo, every column is of that instant
+	now := time.Date(2026, time.July, 20, 14, 59, 59, 0, time.UTC)
+	locations, err := DefaultResolver().aliasLocations("apac", time.UTC, true, now)
+	if err != nil || len(locations) != 1 {
+		t.Fatalf("`aliasLocations(apac)` = %+v, %v", locations, err)
+	}
+	want := locationInfo{
+		place: "Tokyo", city: "Tokyo", country: "Japan", timezone: "Asia/Tokyo",
+		formattedTime: "Mon, 20 Jul 2026 23:59:59", difference: "+9h", day: "today, Mon",
+		status: statusHoliday, holiday: "海の日", daylight: "night, sunrise 04:40",
+	}
+	if got := locations[0]; got != want {
+		t.Fatalf("`aliasLocations(apac)` = %+v, want %+v", got, want)
+	}
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/table"
@@ -9,7 +11,10 @@ import (
 	"github.com/kritibb/ktz/tzdata"
 	"io"
 	"os"
+	"sort"
 	"strings"
+	"text/tabwriter"
+	"time"
 )
 
 // constants and variables for list view
@@ -25,8 +30,6 @@ var (
 	quitTextStyle     = lipgloss.NewStyle().Margin(1, 0, 2, 4)
 )
 
-var locationData locationInfo
-
 // variable for table view
 var baseTableStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
@@ -46,21 +49,79 @@ const (
 	tableView
 )
 
-func (i item) FilterValue() string { return "" }
+func (i item) FilterValue() string { return string(i) }
+
+// zoneItem is a timezone of a country in the picker, described by a representative city,
+// its region (the subdivisions using it or its continent), its current UTC offset and local time.
+type zoneItem struct {
+	zone      string
+	city      string
+	region    string
+	offset    int // The current UTC offset in seconds
+	localTime string
+}
+
+func (z zoneItem) FilterValue() string { return z.city + " " + z.region + " " + z.zone }
 
-type itemDelegate struct{}
+// newZoneItems returns the picker items for the timezones of a country at the instant 'now',
+// sorted by UTC offset.
+func newZoneItems(country string, timezones []string, now time.Time) []list.Item {
+	subdivisions := zoneSubdivisions(country)
+	zones := make([]zoneItem, 0, len(timezones))
+	for _, tz := range timezones {
+		loc, err := loadLocation(tz)
+		if err != nil {
+			continue
+		}
+		localTime := now.In(loc)
+		_, offset := localTime.Zone()
+		region := strings.Join(subdivisions[tz], ", ")
+		if region == "" {
+			region = zoneRegion(tz)
+		}
+		zones = append(zones, zoneItem{
+			zone:      tz,
+			city:      representativeCity(tz),
+			region:    region,
+			offset:    offset,
+			localTime: localTime.Format(config.clockLayout()),
+		})
+	}
+	sort.SliceStable(zones, func(i, j int) bool {
+		if zones[i].offset != zones[j].offset {
+			return zones[i].offset < zones[j].offset
+		}
+		return zones[i].city < zones[j].city
+	})
+	items := make([]list.Item, len(zones))
+	for i, zone := range zones {
+		items[i] = zone
+	}
+	return items
+}
+
+type itemDelegate struct {
+	fitWidth bool // Whether items are shortened to the width of the list
+}
 
 func (d itemDelegate) Height() int                             { return 1 }
 func (d itemDelegate) Spacing() int                            { return 0 }
 func (d itemDelegate) Update(_ tea.Msg, _ *list.Model) tea.Cmd { return nil }
 func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list.Item) {
 
-	i, ok := listItem.(item)
-	if !ok {
+	var str string
+	switch i := listItem.(type) {
+	case item:
+		str = fmt.Sprintf("%d. %s", index+1, i)
+	case zoneItem:
+		str = fmt.Sprintf("%d. %s, %s  %s  %s  (%s)", index+1, i.city, i.region, formatUTCOffset(i.offset), i.localTime, i.zone)
+	default:
 		return
 	}
-
-	str := fmt.Sprintf("%d. %s", index+1, i)
+	// keep long descriptions, like the many states using a zone, on one line
+	if d.fitWidth {
+		str = truncateText(str, m.Width()-4)
+	}
 
 	fn := itemStyle.Render
 	if index == m.Index() {
@@ -72,12 +133,23 @@ func (d itemDelegate) Render(w io.Writer, m list.Model, index int, listItem list
 	fmt.Fprint(w, fn(str))
 }
 
+// truncateText shortens 's' to 'width' runes, ending it with an ellipsis if it was longer.
+func truncateText(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	return string(runes[:width-1]) + "…"
+}
+
 type model struct {
 	list     list.Model
 	table    table.Model
 	choice   string
 	quitting bool
 	state    viewState
+	resolver *Resolver    // The resolver the aliases of the picker's items come from, if any
+	selected locationInfo // The location chosen in the picker, returned by runList
 }
 
 func initialModel(state viewState) model {
@@ -86,7 +158,7 @@ func initialModel(state viewState) model {
 	l := list.New([]list.Item{}, itemDelegate{}, defaultWidth, listHeight)
 	l.Title = fmt.Sprintf("Select one timezone:")
 	l.SetShowStatusBar(false)
-	l.SetFilteringEnabled(false)
+	l.SetFilteringEnabled(true)
 	l.Styles.Title = titleStyle
 	l.Styles.PaginationStyle = paginationStyle
 	l.Styles.HelpStyle = helpStyle
@@ -139,25 +211,39 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
+		// while typing a filter, keys belong to the list
+		if m.state == listView && m.list.FilterState() == list.Filtering {
+			break
+		}
 		switch keypress := msg.String(); keypress {
 		case "q", "ctrl+c":
 			m.quitting = true
 			return m, tea.Quit
 
 		case "enter":
+			if zone, ok := m.list.SelectedItem().(zoneItem); ok && m.state == listView {
+				m.choice = zone.zone
+				m.state = -1
+				m.selected.timezone = zone.zone
+				return m, tea.Quit
+			}
 			if m.state == listView {
 				i, ok := m.list.SelectedItem().(item)
 				if ok {
 					m.choice = string(i)
+					//check if selected option is an alias, which is shown as a table of its places
+					if alias, ok := m.isAlias(m.choice); ok {
+						m.selected.alias = alias
+						m.state = -1
+						return m, tea.Quit
+					}
 					//check if selected option is a country and it has more than one timezones
 					if timezones, ok := tzdata.CountryToIanaTimezone[m.choice]; ok && len(timezones) > 1 {
-						locationData.country = string(i)
+						m.selected.country = string(i)
 						m.state = listView
-						items := make([]list.Item, len(timezones))
-						for idx, tz := range timezones {
-							items[idx] = item(tz)
-						}
-						m.list.SetItems(items)
+						m.list.ResetFilter()
+						m.list.SetItems(newZoneItems(m.choice, timezones, time.Now()))
+						selectZone(&m.list, primaryZone(m.choice))
 						return m, cmd
 					} else {
 						// remove the listview state by picking -1
@@ -165,16 +251,21 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						//if selected option is not a city or a country, but a country's tz in the form of "America/New_York"
 						if tzCountry, ok1 := tzdata.CityToIanaTimezone[m.choice]; !ok1 {
 							if tzList, ok2 := tzdata.CountryToIanaTimezone[m.choice]; !ok2 {
-								locationData.timezone = m.choice
+								if code, ok3 := findSubdivision(m.choice); ok3 {
+									//if selected option is a subdivision like "New South Wales"
+									m.selected = subdivisionLocation(code)
+									return m, tea.Quit
+								}
+								m.selected.timezone = m.choice
 							} else {
-								locationData.country = m.choice
-								locationData.timezone = tzList[0]
+								m.selected.country = m.choice
+								m.selected.timezone = tzList[0]
 							}
 						} else {
 							//if selected option is a city
-							locationData.country = tzCountry["country"]
-							locationData.city = m.choice
-							locationData.timezone = tzCountry["tz"]
+							m.selected.country = canonicalCountry(tzCountry["country"])
+							m.selected.city = m.choice
+							m.selected.timezone = tzCountry["tz"]
 						}
 						return m, tea.Quit
 					}
@@ -194,6 +285,15 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 }
 
+// isAlias reports whether the picked item is an alias of the model's resolver.
+func (m model) isAlias(choice string) (string, bool) {
+	if m.resolver == nil {
+		return "", false
+	}
+	alias, _, ok := m.resolver.findAlias(choice)
+	return alias, ok && alias == choice
+}
+
 func (m model) View() string {
 	switch m.state {
 	case listView:
@@ -211,35 +311,79 @@ func (m model) View() string {
 }
 
 // listViewTz lists(bubbletea simple-list format) all possible timzones returned by prefix search for country/city
+// and returns the chosen location.
 //
 // Parameters:
 //
+//	-r: The resolver the aliases among the timezones come from
 //	-timezones: list of timezones
-func listViewTz(timezones []string) {
-	const defaultWidth = 20
+func listViewTz(r *Resolver, timezones []string) locationInfo {
 	m := initialModel(listView)
+	m.resolver = r
 	//Accumulate items in a slice
 	items := []list.Item{}
 	for _, tz := range timezones {
 		items = append(items, item(tz))
 	}
 	m.list.SetItems(items)
-	if _, err := tea.NewProgram(m).Run(); err != nil {
+	return runList(m)
+}
+
+// listViewCountryTz opens the explorer at the timezones of a country, with a representative
+// city, region, UTC offset and local time per timezone, sorted by offset, and returns the
+// chosen location. The country's primary zone is selected at first; going back browses the
+// other countries of its region.
+//
+// Parameters:
+//
+//	-country: The country name
+func listViewCountryTz(country string) locationInfo {
+	m := newExplorer(time.Now)
+	m.deepest = zoneLevel
+	m.openAt(country)
+	return runExplorer(m)
+}
+
+// selectZone selects the item of the timezone 'tz' in a list of zoneItems, if any.
+func selectZone(l *list.Model, tz string) {
+	for i, listItem := range l.Items() {
+		if zone, ok := listItem.(zoneItem); ok && zone.zone == tz {
+			l.Select(i)
+			return
+		}
+	}
+}
+
+// interactive reports whether the user can pick among several locations: the output
+// is a table and the standard input is a terminal.
+func (r *Resolver) interactive() bool {
+	if r.output != "table" {
+		return false
+	}
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// runList runs the bubbletea program of model 'm' listing its items and returns
+// the location chosen, which is empty if the user quits.
+func runList(m model) locationInfo {
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
 		fmt.Println("Error running program:", err)
 		os.Exit(1)
 	}
+	return final.(model).selected
 }
 
-// renderZoneInfoTable returns a table consisting timezone, datetime, zone abbreviation, if any.
+// renderZoneInfoTable returns a table consisting timezone, datetime, status, the zone abbreviation in effect and holiday, if any.
 //
 // Parameters:
 //
 //	-zoneData: A ZoneInfo type containing formatted time, zone and abbreviation, if any.
 func renderZoneInfoTable(zoneData zoneInfo) {
+	title := fmt.Sprintf("Timezone for %v:", zoneData.timezoneName)
 	if zoneData.abbreviation != "" {
-		fmt.Printf("\n Timezone for %v:", zoneData.abbreviation)
-	} else {
-		fmt.Printf("\n Timezone for %v:", zoneData.timezoneName)
+		title = fmt.Sprintf("Timezone for %v:", zoneData.abbreviation)
 	}
 	m := initialModel(tableView)
 	rows := []table.Row{}
@@ -247,35 +391,41 @@ func renderZoneInfoTable(zoneData zoneInfo) {
 	columns = append(columns,
 		table.Column{Title: "TimeZone", Width: 20},
 		table.Column{Title: "Date/Time", Width: 30},
+		table.Column{Title: "Difference", Width: 20},
+		table.Column{Title: "Status", Width: 10},
 	)
-	if zoneData.abbreviation != "" {
+	row := table.Row{zoneData.timezoneName, zoneData.formattedTime, zoneData.difference, zoneData.status}
+	if zoneData.current != "" {
 		columns = append(columns,
 			table.Column{Title: "Zone Abbr.", Width: 20},
 		)
-		rows = append(rows, table.Row{zoneData.timezoneName, zoneData.formattedTime, zoneData.abbreviation})
-	} else {
-		rows = append(rows, table.Row{zoneData.timezoneName, zoneData.formattedTime})
+		row = append(row, zoneData.current)
 	}
+	if zoneData.holiday != "" {
+		columns = append(columns, table.Column{Title: "Holiday", Width: 25})
+		row = append(row, zoneData.holiday)
+	}
+	if zoneData.sun != nil {
+		columns = append(columns, sunColumns...)
+		row = append(row, zoneData.sun.cells()...)
+	}
+	rows = append(rows, row)
 	if rows != nil && columns != nil {
-		m.table.SetColumns(columns)
-		m.table.SetRows(rows)
-		if _, err := tea.NewProgram(m).Run(); err != nil {
-			fmt.Println("Error running program:", err)
-			os.Exit(1)
-		}
+		runTable(title, m, columns, rows)
 	}
 }
 
-// renderDateTimeTableFromLocation returns a table consisting timezone, country, datetime.
+// renderDateTimeTableFromLocation returns a table consisting timezone, country, datetime, status and holiday, if any.
 //
 // Parameters:
 //
 //	-locationList: A list of country or a city
 func renderDateTimeTableFromLocation(currentLocationData locationInfo) {
-	if currentLocationData.city == "" {
-		fmt.Printf("\n Timezone for %v:", currentLocationData.country)
-	} else {
-		fmt.Printf("\n Timezone for %v:", currentLocationData.city)
+	title := fmt.Sprintf("Timezone for %v:", currentLocationData.city)
+	if currentLocationData.subdivision != "" {
+		title = fmt.Sprintf("Timezone for %v, %v:", currentLocationData.subdivision, currentLocationData.country)
+	} else if currentLocationData.city == "" {
+		title = fmt.Sprintf("Timezone for %v:", currentLocationData.country)
 	}
 	m := initialModel(tableView)
 	row := []table.Row{}
@@ -284,15 +434,172 @@ func renderDateTimeTableFromLocation(currentLocationData locationInfo) {
 		table.Column{Title: "TimeZone", Width: 20},
 		table.Column{Title: "Country", Width: 25},
 		table.Column{Title: "Date/Time", Width: 30},
+		table.Column{Title: "Difference", Width: 20},
+		table.Column{Title: "Status", Width: 10},
 	)
-	row = append(row, table.Row{currentLocationData.timezone, currentLocationData.country, currentLocationData.formattedTime})
+	cells := table.Row{currentLocationData.timezone, currentLocationData.country, currentLocationData.formattedTime, currentLocationData.difference, currentLocationData.status}
+	if currentLocationData.holiday != "" {
+		columns = append(columns, table.Column{Title: "Holiday", Width: 25})
+		cells = append(cells, currentLocationData.holiday)
+	}
+	if currentLocationData.sun != nil {
+		columns = append(columns, sunColumns...)
+		cells = append(cells, currentLocationData.sun.cells()...)
+	}
+	row = append(row, cells)
 	if row != nil && columns != nil {
+		runTable(title, m, columns, row)
+	}
+
+}
+
+// sunColumns are the columns of the sun times shown by lookups with -daylight.
+var sunColumns = []table.Column{
+	{Title: "Sunrise", Width: 12},
+	{Title: "Sunset", Width: 12},
+	{Title: "Solar Noon", Width: 12},
+	{Title: "Day Length", Width: 12},
+}
+
+// cells returns the cells of the sun times under sunColumns.
+func (d solarDay) cells() table.Row {
+	return table.Row{d.formatSunTime(d.sunrise), d.formatSunTime(d.sunset), d.noon.Format(config.hourLayout()), d.formatDayLength()}
+}
+
+// renderLocationsTable returns a table with one row per location, e.g. the places of an alias.
+//
+// Parameters:
+//
+//	-title: The title printed above the table
+//	-locations: The locations to show
+func renderLocationsTable(title string, locations []locationInfo) {
+	m := initialModel(tableView)
+	columns := []table.Column{
+		{Title: "Place", Width: 20},
+		{Title: "TimeZone", Width: 20},
+		{Title: "Country", Width: 25},
+		{Title: "Date/Time", Width: 30},
+		{Title: "Difference", Width: 12},
+		{Title: "Day", Width: 30},
+		{Title: "Status", Width: 10},
+	}
+	// the Holiday and Daylight columns are only shown if some location has one
+	withHoliday, withDaylight := false, false
+	for _, location := range locations {
+		withHoliday = withHoliday || location.holiday != ""
+		withDaylight = withDaylight || location.daylight != ""
+	}
+	if withHoliday {
+		columns = append(columns, table.Column{Title: "Holiday", Width: 25})
+	}
+	if withDaylight {
+		columns = append(columns, table.Column{Title: "Daylight", Width: 28})
+	}
+	rows := make([]table.Row, 0, len(locations))
+	for _, location := range locations {
+		row := table.Row{location.place, location.timezone, location.country, location.formattedTime, location.difference, location.day, location.status}
+		if withHoliday {
+			row = append(row, location.holiday)
+		}
+		if withDaylight {
+			row = append(row, location.daylight)
+		}
+		rows = append(rows, row)
+	}
+	m.table.SetHeight(len(rows))
+	runTable(title, m, columns, rows)
+}
+
+// renderDifferenceTable renders the time difference between two places.
+//
+// Parameters:
+//
+//	-placeA, placeB: The places as given by the user
+//	-rows: The rows returned by differenceRows
+func renderDifferenceTable(placeA, placeB string, rows [][]string) {
+	m := initialModel(tableView)
+	columns := []table.Column{
+		{Title: "When", Width: 36},
+		{Title: placeA, Width: 28},
+		{Title: placeB, Width: 28},
+		{Title: "Difference", Width: 20},
+	}
+	tableRows := make([]table.Row, len(rows))
+	for i, row := range rows {
+		tableRows[i] = row
+	}
+	m.table.SetHeight(len(tableRows))
+	runTable(fmt.Sprintf("Time difference from %v to %v:", placeA, placeB), m, columns, tableRows)
+}
+
+// runTable shows the given columns and rows in the configured output mode:
+// the bubbletea table of model 'm', plain aligned text or JSON.
+func runTable(title string, m model, columns []table.Column, rows []table.Row) {
+	renderTable(config.Output, title, m, columns, rows)
+}
+
+// renderTable shows the given columns and rows in the output mode 'output' like runTable,
+// which may also be "csv".
+func renderTable(output, title string, m model, columns []table.Column, rows []table.Row) {
+	switch output {
+	case "csv":
+		writeCSVTable(os.Stdout, columns, rows)
+	case "plain":
+		fmt.Printf("%v\n", title)
+		writePlainTable(os.Stdout, columns, rows)
+	case "json":
+		writeJSONTable(os.Stdout, columns, rows)
+	default:
+		fmt.Printf("\n %v", title)
 		m.table.SetColumns(columns)
-		m.table.SetRows(row)
+		m.table.SetRows(rows)
 		if _, err := tea.NewProgram(m).Run(); err != nil {
 			fmt.Println("Error running program:", err)
 			os.Exit(1)
 		}
 	}
+}
+
+// writeCSVTable writes the columns and rows as CSV with a header line.
+func writeCSVTable(w io.Writer, columns []table.Column, rows []table.Row) {
+	cw := csv.NewWriter(w)
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+	cw.Write(titles)
+	for _, row := range rows {
+		cw.Write(row)
+	}
+	cw.Flush()
+}
+
+// writePlainTable writes the columns and rows as text aligned with tabs.
+func writePlainTable(w io.Writer, columns []table.Column, rows []table.Row) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	titles := make([]string, len(columns))
+	for i, column := range columns {
+		titles[i] = column.Title
+	}
+	fmt.Fprintln(tw, strings.Join(titles, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+}
 
+// writeJSONTable writes the rows as a JSON array of objects keyed by column title.
+func writeJSONTable(w io.Writer, columns []table.Column, rows []table.Row) {
+	objects := make([]map[string]string, len(rows))
+	for i, row := range rows {
+		objects[i] = map[string]string{}
+		for j, column := range columns {
+			if j < len(row) {
+				objects[i][column.Title] = row[j]
+			}
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(objects)
 }
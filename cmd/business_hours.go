@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// The statuses of a place, telling whether it's a good time to call.
+const (
+	statusWorking = "working"
+	statusMorning = "morning"
+	statusEvening = "evening"
+	statusNight   = "night"
+	statusWeekend = "weekend"
+	statusHoliday = "holiday"
+)
+
+// The night, when people are assumed asleep, in minutes after midnight.
+const (
+	nightStart = 22 * 60
+	nightEnd   = 7 * 60
+)
+
+// workingHours is a working day from 'start' to 'end', in minutes after midnight.
+// An 'end' before 'start' is a night shift ending the next day.
+type workingHours struct {
+	start, end int
+}
+
+// defaultWorkingHours are the working hours used when none are configured.
+var defaultWorkingHours = workingHours{9 * 60, 17 * 60}
+
+// parseWorkingHours parses working hours like '09:00-17:00' or '8:30am-5pm'.
+func parseWorkingHours(s string) (workingHours, error) {
+	from, to, ok := strings.Cut(strings.ReplaceAll(s, " ", ""), "-")
+	if !ok {
+		return workingHours{}, fmt.Errorf("Invalid working hours '%s', use e.g. 09:00-17:00.", s)
+	}
+	startHour, startMinute, err := parseClock(from)
+	if err != nil {
+		return workingHours{}, err
+	}
+	endHour, endMinute, err := parseClock(to)
+	if err != nil {
+		return workingHours{}, err
+	}
+	hours := workingHours{startHour*60 + startMinute, endHour*60 + endMinute}
+	if hours.start == hours.end {
+		return workingHours{}, fmt.Errorf("Invalid working hours '%s', they are empty.", s)
+	}
+	return hours, nil
+}
+
+// String formats the working hours like '09:00-17:00'.
+func (h workingHours) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", h.start/60, h.start%60, h.end/60, h.end%60)
+}
+
+// contains reports whether the minute after midnight is within the working hours.
+func (h workingHours) contains(minute int) bool {
+	if h.start < h.end {
+		return h.start <= minute && minute < h.end
+	}
+	return minute >= h.start || minute < h.end
+}
+
+// parseWeekday parses a weekday name like 'Fri' or 'friday'.
+func parseWeekday(name string) (time.Weekday, error) {
+	lower := strings.ToLower(name)
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		full := strings.ToLower(day.String())
+		if lower == full || lower == full[:3] {
+			return day, nil
+		}
+	}
+	return 0, fmt.Errorf("Invalid weekday '%s', use e.g. Fri or Friday.", name)
+}
+
+// formatWeekdays formats weekdays by their short names, e.g. ["Fri", "Sat"].
+func formatWeekdays(days []time.Weekday) []string {
+	names := make([]string, len(days))
+	for i, day := range days {
+		names[i] = day.String()[:3]
+	}
+	return names
+}
+
+// statusAt returns the status of a place at 't', the time in the place: weekend on the
+// weekend days, working within the working hours, otherwise night between 22:00 and
+// 07:00 or else morning before and evening after work.
+func statusAt(t time.Time, hours workingHours, weekend []time.Weekday) string {
+	for _, day := range weekend {
+		if t.Weekday() == day {
+			return statusWeekend
+		}
+	}
+	minute := t.Hour()*60 + t.Minute()
+	switch {
+	case hours.contains(minute):
+		return statusWorking
+	case minute >= nightStart || minute < nightEnd:
+		return statusNight
+	case minute < hours.start:
+		return statusMorning
+	default:
+		return statusEvening
+	}
+}
+
+// zoneCountry returns the first country, alphabetically, using the timezone 'tz', if any.
+func zoneCountry(tz string) string {
+	countries := sortedKeys(tzdata.CountryToIanaTimezone)
+	for _, country := range countries {
+		for _, zone := range tzdata.CountryToIanaTimezone[country] {
+			if zone == tz {
+				return country
+			}
+		}
+	}
+	return ""
+}
+
+// placeSetting returns the value configured for a location in a section keyed by places
+// like 'hours' or 'weekends'. The most specific match wins: the place as given, then the
+// city, the subdivision, the timezone and the country (by name or code), then 'default'.
+func placeSetting[V any](settings map[string]V, location locationInfo) (V, bool) {
+	country := location.country
+	if country == "" {
+		country = zoneCountry(location.timezone)
+	}
+	keys := sortedKeys(settings)
+	for _, name := range []string{location.place, location.city, location.subdivision, location.timezone, country} {
+		if name == "" {
+			continue
+		}
+		for _, key := range keys {
+			if cleanWord(key) == cleanWord(name) {
+				return settings[key], true
+			}
+			if name == country && isCountryCode(key) && countryOfCode(key) == country {
+				return settings[key], true
+			}
+		}
+	}
+	value, ok := settings["default"]
+	return value, ok
+}
+
+// countryOfCode returns the country of an alpha-2 or alpha-3 code, by its name in
+// tzdata.CountryToIanaTimezone.
+func countryOfCode(code string) string {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if country, ok := tzdata.Alpha2ToCountry[code]; ok {
+		return canonicalCountry(country)
+	}
+	if country, ok := tzdata.Alpha3ToCountry[code]; ok {
+		return canonicalCountry(country)
+	}
+	return ""
+}
+
+// locationSchedule returns the working hours and the weekend of a location:
+// the configured ones if any, otherwise 09:00-17:00 and the weekend of its country.
+func (r *Resolver) locationSchedule(location locationInfo) (workingHours, []time.Weekday) {
+	hours, ok := placeSetting(r.hours, location)
+	if !ok {
+		hours = defaultWorkingHours
+	}
+	weekend, ok := placeSetting(r.weekends, location)
+	if !ok {
+		country := location.country
+		if country == "" {
+			country = zoneCountry(location.timezone)
+		}
+		if weekend, ok = tzdata.CountryWeekend[country]; !ok {
+			weekend = tzdata.DefaultWeekend
+		}
+	}
+	return hours, weekend
+}
+
+// locationStatus returns the status of a location at the instant 't', which is
+// holiday on the public holidays of its country.
+func (r *Resolver) locationStatus(location locationInfo, t time.Time) (string, error) {
+	loc, err := loadLocation(location.timezone)
+	if err != nil {
+		return "", err
+	}
+	if r.holidayOn(location, t.In(loc)) != "" {
+		return statusHoliday, nil
+	}
+	hours, weekend := r.locationSchedule(location)
+	return statusAt(t.In(loc), hours, weekend), nil
+}
+
+// OkToCall prints the status of a place now and reports whether it's within its working hours.
+func OkToCall(r *Resolver, place string) (bool, error) {
+	location, err := r.resolvePlace(place)
+	if err != nil {
+		return false, err
+	}
+	location.place = place
+	loc, err := loadLocation(location.timezone)
+	if err != nil {
+		return false, err
+	}
+	hours, weekend := r.locationSchedule(location)
+	now := time.Now().In(loc)
+	status, err := r.locationStatus(location, now)
+	if err != nil {
+		return false, err
+	}
+	ok := status == statusWorking
+	if config.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return ok, encoder.Encode(map[string]any{
+			"Place":     place,
+			"TimeZone":  location.timezone,
+			"Date/Time": now.Format(config.shortTimeLayout()),
+			"Status":    status,
+			"Hours":     hours.String(),
+			"Weekend":   formatWeekdays(weekend),
+			"OK":        ok,
+		})
+	}
+	fmt.Printf("%v: %v, %v (hours %v, weekend %v)\n", place, status, now.Format(config.clockLayout()),
+		hours, strings.Join(formatWeekdays(weekend), "/"))
+	return ok, nil
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+func TestParseWorkingHours(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    workingHours
+		wantErr bool
+	}{
+		{"09:00-17:00", workingHours{9 * 60, 17 * 60}, false},
+		{"8:30am - 5pm", workingHours{8*60 + 30, 17 * 60}, false},
+		{"22:00-06:00", workingHours{22 * 60, 6 * 60}, false},
+		{"09:00", workingHours{}, true},
+		{"09:00-09:00", workingHours{}, true},
+		{"9-17", workingHours{}, true},
+	}
+	for _, tt := range tests {
+		got, err := parseWorkingHours(tt.input)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Fatalf("`parseWorkingHours(%q)` = %v, %v", tt.input, got, err)
+		}
+	}
+	if got := (workingHours{8*60 + 30, 17 * 60}).String(); got != "08:30-17:00" {
+		t.Fatalf("`workingHours.String()` = %q", got)
+	}
+}
+
+func TestStatusAt(t *testing.T) {
+	weekend := []time.Weekday{time.Friday, time.Saturday}
+	nightShift := workingHours{22 * 60, 6 * 60}
+	tests := []struct {
+		hour, minute int
+		day          int // a day of October 2026, the 18th being a Sunday
+		hours        workingHours
+		want         string
+	}{
+		{9, 0, 18, defaultWorkingHours, statusWorking},
+		{16, 59, 18, defaultWorkingHours, statusWorking},
+		{17, 0, 18, defaultWorkingHours, statusEvening},
+		{21, 59, 18, defaultWorkingHours, statusEvening},
+		{22, 0, 18, defaultWorkingHours, statusNight},
+		{6, 59, 18, defaultWorkingHours, statusNight},
+		{7, 0, 18, defaultWorkingHours, statusMorning},
+		{12, 0, 16, defaultWorkingHours, statusWeekend},
+		{12, 0, 17, defaultWorkingHours, statusWeekend},
+		{23, 0, 18, nightShift, statusWorking},
+		{5, 0, 18, nightShift, statusWorking},
+		{12, 0, 18, nightShift, statusMorning},
+	}
+	for _, tt := range tests {
+		at := time.Date(2026, time.October, tt.day, tt.hour, tt.minute, 0, 0, time.UTC)
+		if got := statusAt(at, tt.hours, weekend); got != tt.want {
+			t.Fatalf("`statusAt(%v, %v)` = %q, want %q", at, tt.hours, got, tt.want)
+		}
+	}
+}
+
+func TestLocationSchedule(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+
+	tests := []struct {
+		location    locationInfo
+		wantHours   workingHours
+		wantWeekend []time.Weekday
+	}{
+		{locationInfo{city: "Kathmandu", country: "Nepal", timezone: "Asia/Kathmandu"}, defaultWorkingHours, []time.Weekday{time.Saturday}},
+		{locationInfo{timezone: "Asia/Riyadh"}, defaultWorkingHours, []time.Weekday{time.Friday, time.Saturday}},
+		{locationInfo{timezone: "Europe/Berlin"}, defaultWorkingHours, tzdata.DefaultWeekend},
+	}
+	for _, tt := range tests {
+		hours, weekend := DefaultResolver().locationSchedule(tt.location)
+		if hours != tt.wantHours || !equalWeekdays(weekend, tt.wantWeekend) {
+			t.Fatalf("`locationSchedule(%+v)` = %v, %v", tt.location, hours, weekend)
+		}
+	}
+
+	for _, entry := range []struct{ section, key, value string }{
+		{"hours", "default", "08:00-16:00"},
+		{"hours", "NP", "10:00-17:00"},
+		{"hours", "Kathmandu", "11:00-18:00"},
+		{"weekends", "Asia/Riyadh", "Sun"},
+	} {
+		if err := configSections[entry.section](&config, entry.key, entry.value); err != nil {
+			t.Fatalf("setting %v.%v: %v", entry.section, entry.key, err)
+		}
+	}
+	tests = []struct {
+		location    locationInfo
+		wantHours   workingHours
+		wantWeekend []time.Weekday
+	}{
+		{locationInfo{city: "Kathmandu", country: "Nepal", timezone: "Asia/Kathmandu"}, workingHours{11 * 60, 18 * 60}, []time.Weekday{time.Saturday}},
+		{locationInfo{country: "Nepal", timezone: "Asia/Kathmandu"}, workingHours{10 * 60, 17 * 60}, []time.Weekday{time.Saturday}},
+		{locationInfo{timezone: "Asia/Riyadh"}, workingHours{8 * 60, 16 * 60}, []time.Weekday{time.Sunday}},
+	}
+	for _, tt := range tests {
+		hours, weekend := DefaultResolver().locationSchedule(tt.location)
+		if hours != tt.wantHours || !equalWeekdays(weekend, tt.wantWeekend) {
+			t.Fatalf("`locationSchedule(%+v)` = %v, %v", tt.location, hours, weekend)
+		}
+	}
+}
+
+func TestCountryWeekendKeys(t *testing.T) {
+	for country := range tzdata.CountryWeekend {
+		if _, ok := tzdata.CountryToIanaTimezone[country]; !ok {
+			t.Fatalf("`tzdata.CountryWeekend` has unknown country %q", country)
+		}
+	}
+}
+
+func equalWeekdays(a, b []time.Weekday) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// CalendarOptions holds the options of the `cal` command.
+type CalendarOptions struct {
+	Month string // The month like '2026-11'; empty means the current month
+	At    string // A time like '09:30 Kathmandu' to show converted on each day, if any
+}
+
+// calendarDay is a day in the calendar of a place.
+type calendarDay struct {
+	day       int           // The day of the month
+	offset    time.Duration // The UTC offset at the start of the day
+	dstChange bool          // The UTC offset of the place changes on this day
+	newOffset time.Duration // The UTC offset after the change, if any
+	at        string        // The time of the moment in the place on this day like '20:45' or '00:15+1'
+	shifted   bool          // The converted time differs from the one on the day before
+}
+
+// calendarStyles are the styles of the calendar grid.
+type calendarStyles struct {
+	title, weekday, dstChange, shifted lipgloss.Style
+}
+
+// newCalendarStyles returns the calendar styles, without colors if 'styled' is false.
+func newCalendarStyles(styled bool) calendarStyles {
+	if !styled {
+		plain := lipgloss.NewStyle()
+		return calendarStyles{plain, plain, plain, plain}
+	}
+	return calendarStyles{
+		title:     lipgloss.NewStyle().Bold(true),
+		weekday:   lipgloss.NewStyle().Foreground(lipgloss.Color("240")),
+		dstChange: lipgloss.NewStyle().Foreground(lipgloss.Color("170")),
+		shifted:   lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("214")),
+	}
+}
+
+// ShowCalendar prints a month grid for each place, marking the days the UTC offset changes.
+// With 'opts.At', each day shows that time converted to the place, highlighting the days
+// it shifts, e.g. the weeks a standup drifts by an hour between DST changes.
+func ShowCalendar(r *Resolver, places []string, opts CalendarOptions) {
+	year, month, err := parseCalendarMonth(opts.Month, time.Now())
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	var at *moment
+	if opts.At != "" {
+		m, err := r.parseCalendarAt(opts.At)
+		if err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+		at = &m
+	}
+	locations, err := r.resolvePlaces(places)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+
+	if config.Output == "json" {
+		columns := []table.Column{{Title: "Place"}, {Title: "TimeZone"}, {Title: "Date"}, {Title: "Offset"}, {Title: "DST Change"}, {Title: "At"}}
+		var rows []table.Row
+		for _, location := range locations {
+			loc, err := loadLocation(location.timezone)
+			if err != nil {
+				fmt.Printf("\nError: %v\n", err)
+				return
+			}
+			for _, day := range calendarDays(loc, year, month, at) {
+				rows = append(rows, table.Row{
+					location.place, location.timezone,
+					time.Date(year, month, day.day, 0, 0, 0, 0, time.UTC).Format("2006-01-02"),
+					formatUTCOffset(int(day.offset.Seconds())), map[bool]string{true: "yes", false: "no"}[day.dstChange], day.at,
+				})
+			}
+		}
+		writeJSONTable(os.Stdout, columns, rows)
+		return
+	}
+
+	styles := newCalendarStyles(config.Output != "plain")
+	if at != nil {
+		place := at.place
+		if place == "" {
+			place = "home"
+		}
+		fmt.Println(styles.title.Render(fmt.Sprintf("%02d:%02d in %v (%v) on each day:", at.hour, at.minute, place, at.loc)))
+		fmt.Println()
+	}
+	for _, location := range locations {
+		loc, err := loadLocation(location.timezone)
+		if err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+		title := fmt.Sprintf("%v (%v)", location.place, location.timezone)
+		writeCalendar(os.Stdout, title, year, month, calendarDays(loc, year, month, at), at != nil, styles)
+		fmt.Println()
+	}
+	legend := "* the UTC offset changes that day"
+	if at != nil {
+		legend += ", ! the time differs from the day before"
+	}
+	fmt.Println(styles.weekday.Render(legend))
+}
+
+// parseCalendarAt parses the -at time of the calendar like '09:30 Kathmandu'. As it's shown
+// on every day of the month, a date, weekday or 'tomorrow' is an error.
+func (r *Resolver) parseCalendarAt(s string) (moment, error) {
+	m, err := r.parseMoment(s)
+	if err != nil {
+		return m, err
+	}
+	if !m.date.IsZero() || m.onWeekday || m.tomorrow {
+		return m, fmt.Errorf("The time '%s' has a date, but -at is shown on every day; use e.g. \"09:30 Kathmandu\" and -month for the month.", s)
+	}
+	return m, nil
+}
+
+// parseCalendarMonth parses a month like '2026-11'; an empty month gives the month of 'now'.
+func parseCalendarMonth(month string, now time.Time) (int, time.Month, error) {
+	if month == "" {
+		return now.Year(), now.Month(), nil
+	}
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return 0, 0, fmt.Errorf("Invalid month '%s', use e.g. 2026-11.", month)
+	}
+	return t.Year(), t.Month(), nil
+}
+
+// calendarDays returns the days of a month in 'loc'. With a moment 'at', the days are those
+// of the moment's location and each day holds the moment converted to 'loc'.
+func calendarDays(loc *time.Location, year int, month time.Month, at *moment) []calendarDay {
+	var days []calendarDay
+	previous := ""
+	for day := 1; day <= daysIn(year, month); day++ {
+		start := time.Date(year, month, day, 0, 0, 0, 0, loc)
+		change, ok := nextOffsetChange(loc, start.Add(-time.Second))
+		_, offset := start.Zone()
+		calendarDay := calendarDay{
+			day:       day,
+			offset:    time.Duration(offset) * time.Second,
+			dstChange: ok && change.Before(start.AddDate(0, 0, 1)),
+		}
+		if calendarDay.dstChange {
+			_, newOffset := change.Zone()
+			calendarDay.newOffset = time.Duration(newOffset) * time.Second
+		}
+		if at != nil {
+			converted := at.on(year, month, day).In(loc)
+			calendarDay.at = converted.Format(config.hourLayout())
+			// the day in the place may differ from the day of the moment
+			reference := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+			if days := dayDifference(reference, converted); days != 0 {
+				calendarDay.at += fmt.Sprintf("%+d", days)
+			}
+			calendarDay.shifted = previous != "" && calendarDay.at != previous
+			previous = calendarDay.at
+		}
+		days = append(days, calendarDay)
+	}
+	return days
+}
+
+// daysIn returns the number of days of a month.
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// writeCalendar writes a month grid with weeks starting on Monday, followed by the offset changes.
+func writeCalendar(w io.Writer, title string, year int, month time.Month, days []calendarDay, withAt bool, styles calendarStyles) {
+	texts := make([]string, len(days))
+	for i, day := range days {
+		text := fmt.Sprintf("%2d", day.day)
+		if day.dstChange {
+			text = styles.dstChange.Render(text + "*")
+		} else {
+			text += " "
+		}
+		if withAt {
+			at := day.at
+			if day.shifted {
+				at = styles.shifted.Render(at + "!")
+			}
+			text += " " + at
+		}
+		texts[i] = text
+	}
+	// the cells are wide enough for the widest day with a space after it
+	cellWidth := 5
+	if withAt {
+		cellWidth = 14
+	}
+	for _, text := range texts {
+		cellWidth = max(cellWidth, lipgloss.Width(text)+1)
+	}
+	cell := lipgloss.NewStyle().Width(cellWidth)
+
+	fmt.Fprintln(w, styles.title.Render(fmt.Sprintf("%v, %v %d", title, month, year)))
+	var header []string
+	for _, weekday := range []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"} {
+		header = append(header, cell.Render(styles.weekday.Render(weekday)))
+	}
+	fmt.Fprintln(w, strings.TrimRight(lipgloss.JoinHorizontal(lipgloss.Top, header...), " "))
+
+	// Monday is the first column
+	column := (int(time.Date(year, month, 1, 0, 0, 0, 0, time.UTC).Weekday()) + 6) % 7
+	week := make([]string, column)
+	for i := range week {
+		week[i] = cell.Render("")
+	}
+	for _, text := range texts {
+		week = append(week, cell.Render(text))
+		if len(week) == 7 {
+			fmt.Fprintln(w, strings.TrimRight(lipgloss.JoinHorizontal(lipgloss.Top, week...), " "))
+			week = week[:0]
+		}
+	}
+	if len(week) > 0 {
+		fmt.Fprintln(w, strings.TrimRight(lipgloss.JoinHorizontal(lipgloss.Top, week...), " "))
+	}
+
+	for _, day := range days {
+		if day.dstChange {
+			fmt.Fprintf(w, "%v %d: %v -> %v\n", month.String()[:3], day.day,
+				formatUTCOffset(int(day.offset.Seconds())), formatUTCOffset(int(day.newOffset.Seconds())))
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCalendarDays(t *testing.T) {
+	newYork, _ := loadLocation("America/New_York")
+	kathmandu, _ := loadLocation("Asia/Kathmandu")
+	at := &moment{hour: 9, minute: 30, loc: kathmandu}
+
+	days := calendarDays(newYork, 2026, time.November, at)
+	if len(days) != 30 {
+		t.Fatalf("`calendarDays()` returned %d days, want 30", len(days))
+	}
+	if !days[0].dstChange || days[0].newOffset != -5*time.Hour || days[0].offset != -4*time.Hour {
+		t.Fatalf("`calendarDays()` Nov 1 = %+v, want a change from -4h to -5h", days[0])
+	}
+	if days[0].at != "11:45PM-1" || days[1].at != "10:45PM-1" || !days[1].shifted || days[2].shifted {
+		t.Fatalf("`calendarDays()` times = %+v, %+v, %+v", days[0], days[1], days[2])
+	}
+	for _, day := range calendarDays(kathmandu, 2026, time.November, nil) {
+		if day.dstChange || day.at != "" {
+			t.Fatalf("`calendarDays(Asia/Kathmandu)` day %d = %+v", day.day, day)
+		}
+	}
+}
+
+func TestWriteCalendar(t *testing.T) {
+	berlin, _ := loadLocation("Europe/Berlin")
+	var b bytes.Buffer
+	writeCalendar(&b, "Berlin", 2026, time.March, calendarDays(berlin, 2026, time.March, nil), false, newCalendarStyles(false))
+	lines := strings.Split(b.String(), "\n")
+	want := []string{
+		"Berlin, March 2026",
+		"Mon  Tue  Wed  Thu  Fri  Sat  Sun",
+		"                               1",
+		"23   24   25   26   27   28   29*",
+		"Mar 29: UTC+01:00 -> UTC+02:00",
+	}
+	for _, line := range want {
+		found := false
+		for _, got := range lines {
+			found = found || got == line
+		}
+		if !found {
+			t.Fatalf("`writeCalendar()` output lacks %q:\n%v", line, b.String())
+		}
+	}
+}
+
+func TestWriteCalendarAt(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	newYork, _ := loadLocation("America/New_York")
+	kathmandu, _ := loadLocation("Asia/Kathmandu")
+	at := &moment{hour: 9, minute: 30, loc: kathmandu}
+	var b bytes.Buffer
+	writeCalendar(&b, "New York", 2026, time.November, calendarDays(newYork, 2026, time.November, at), true, newCalendarStyles(false))
+	// a shifted time of the day before keeps a space before the next day
+	want := " 1* 11:45PM-1"
+	if !strings.Contains(b.String(), "\n 2  10:45PM-1!  3  10:45PM-1") || !strings.Contains(b.String(), want) {
+		t.Fatalf("`writeCalendar()` output lacks the spaced 12 hour times:\n%v", b.String())
+	}
+}
+
+func TestParseCalendarMonth(t *testing.T) {
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if year, month, err := parseCalendarMonth("", now); err != nil || year != 2026 || month != time.October {
+		t.Fatalf("`parseCalendarMonth()` = %v, %v, %v", year, month, err)
+	}
+	if year, month, err := parseCalendarMonth("2027-02", now); err != nil || year != 2027 || month != time.February {
+		t.Fatalf("`parseCalendarMonth(2027-02)` = %v, %v, %v", year, month, err)
+	}
+	if _, _, err := parseCalendarMonth("November", now); err == nil {
+		t.Fatalf("`parseCalendarMonth(November)` returned no error")
+	}
+}
+
+func TestParseCalendarAt(t *testing.T) {
+	tests := []struct {
+		given   string
+		wantErr bool
+	}{
+		{"09:30 Kathmandu", false},
+		{"today 9:30pm London", false},
+		{"2026-12-25 09:30 Kathmandu", true},
+		{"friday 17:00 London", true},
+		{"tomorrow 09:00", true},
+	}
+	for _, test := range tests {
+		if _, err := DefaultResolver().parseCalendarAt(test.given); (err != nil) != test.wantErr {
+			t.Fatalf("`parseCalendarAt(%v)` returned error %v, want an error: %v", test.given, err, test.wantErr)
+		}
+	}
+}
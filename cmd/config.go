@@ -0,0 +1,538 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// Config holds the user's defaults, loaded from the configuration file and
+// overridden by environment variables.
+type Config struct {
+	Format        string                    // The Go time layout of the Date/Time column; overrides Clock
+	Clock         int                       // 12 or 24 hour clock
+	Home          string                    // The place time differences are computed against; empty means the system zone
+	Output        string                    // The output mode: table, plain or json
+	Abbreviations map[string]string         // Preferred meanings of zone abbreviations (e.g. IST = Asia/Kolkata)
+	Aliases       map[string][]string       // User-defined names for a place or a group of places
+	Hours         map[string]workingHours   // Working hours per place, or for every place under 'default'
+	Weekends      map[string][]time.Weekday // Weekend days per place, overriding those of its country
+	Holidays      map[string][]string       // Holiday files (.ics or .json) per alpha-2 code, or for every country under 'all'
+
+	holidays map[string][]holidayRange // The holidays read from the Holidays files when loading, per key
+	dir      string                    // The directory of the configuration file, relative Holidays files are in
+}
+
+// ConfigError is returned for an invalid configuration value.
+// It points to the offending key and, for the configuration file, its line.
+type ConfigError struct {
+	Source string // The configuration file or environment variable
+	Line   int    // The line in the configuration file, 0 if unknown
+	Key    string
+	Reason string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s: %s", e.Source, e.Line, e.Key, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Source, e.Key, e.Reason)
+}
+
+// configSetting describes a top-level configuration key.
+type configSetting struct {
+	env   string                             // The environment variable overriding the key
+	apply func(cfg *Config, value any) error // Validates the value and stores it in the config
+	get   func(cfg Config) any               // Returns the current value
+}
+
+// configSettings lists the top-level configuration keys.
+var configSettings = map[string]configSetting{
+	"format": {
+		env: "KTZ_FORMAT",
+		apply: func(cfg *Config, value any) error {
+			format, err := stringValue(value)
+			if err != nil {
+				return err
+			}
+			if format != "" && !isTimeLayout(format) {
+				return fmt.Errorf("%q is not a Go time layout like \"Mon, 02 Jan 2006 15:04\"", format)
+			}
+			cfg.Format = format
+			return nil
+		},
+		get: func(cfg Config) any { return cfg.Format },
+	},
+	"clock": {
+		env: "KTZ_CLOCK",
+		apply: func(cfg *Config, value any) error {
+			var clock int64
+			switch v := value.(type) {
+			case int64:
+				clock = v
+			case string:
+				clock, _ = strconv.ParseInt(strings.TrimSuffix(strings.ToLower(v), "h"), 10, 64)
+			}
+			if clock != 12 && clock != 24 {
+				return fmt.Errorf("must be 12 or 24")
+			}
+			cfg.Clock = int(clock)
+			return nil
+		},
+		get: func(cfg Config) any { return int64(cfg.Clock) },
+	},
+	"home": {
+		env: "KTZ_HOME",
+		apply: func(cfg *Config, value any) error {
+			home, err := stringValue(value)
+			if err != nil {
+				return err
+			}
+			if home != "" {
+				if _, err := NewResolver(*cfg).resolvePlace(home); err != nil {
+					return err
+				}
+			}
+			cfg.Home = home
+			return nil
+		},
+		get: func(cfg Config) any { return cfg.Home },
+	},
+	"output": {
+		env: "KTZ_OUTPUT",
+		apply: func(cfg *Config, value any) error {
+			output, err := stringValue(value)
+			if err != nil {
+				return err
+			}
+			switch output {
+			case "table", "plain", "json":
+				cfg.Output = output
+				return nil
+			}
+			return fmt.Errorf("must be one of table, plain or json")
+		},
+		get: func(cfg Config) any { return cfg.Output },
+	},
+}
+
+// configSections lists the configuration tables whose keys are chosen by the user,
+// with a function validating and storing one key of the table.
+var configSections = map[string]func(cfg *Config, key string, value any) error{
+	"abbreviations": func(cfg *Config, key string, value any) error {
+		tz, err := stringValue(value)
+		if err != nil {
+			return err
+		}
+		if _, err := loadLocation(tz); err != nil {
+			return fmt.Errorf("unknown timezone %q", tz)
+		}
+		cfg.Abbreviations[strings.ToUpper(key)] = tz
+		return nil
+	},
+	"aliases": func(cfg *Config, key string, value any) error {
+		places, ok := value.([]string)
+		if place, isString := value.(string); isString {
+			places, ok = []string{place}, true
+		}
+		if !ok || len(places) == 0 {
+			return fmt.Errorf("must be a place or a list of places")
+		}
+		for _, place := range places {
+			if _, err := NewResolver(*cfg).resolveBuiltinPlace(place); err != nil {
+				return err
+			}
+		}
+		cfg.Aliases[key] = places
+		return nil
+	},
+	"hours": func(cfg *Config, key string, value any) error {
+		if err := validatePlaceKey(*cfg, key); err != nil {
+			return err
+		}
+		s, err := stringValue(value)
+		if err != nil {
+			return err
+		}
+		hours, err := parseWorkingHours(s)
+		if err != nil {
+			return err
+		}
+		cfg.Hours[key] = hours
+		return nil
+	},
+	"weekends": func(cfg *Config, key string, value any) error {
+		if err := validatePlaceKey(*cfg, key); err != nil {
+			return err
+		}
+		names, ok := value.([]string)
+		if name, isString := value.(string); isString {
+			names, ok = []string{name}, true
+		}
+		if !ok {
+			return fmt.Errorf("must be a weekday or a list of weekdays")
+		}
+		days := []time.Weekday{}
+		for _, name := range names {
+			day, err := parseWeekday(name)
+			if err != nil {
+				return err
+			}
+			days = append(days, day)
+		}
+		cfg.Weekends[key] = days
+		return nil
+	},
+	"holidays": func(cfg *Config, key string, value any) error {
+		if key != allCountries {
+			if _, ok := tzdata.Alpha2ToCountry[strings.ToUpper(key)]; !ok {
+				return fmt.Errorf("must be an alpha-2 country code like NP or '%s'", allCountries)
+			}
+			key = strings.ToUpper(key)
+		}
+		paths, ok := value.([]string)
+		if path, isString := value.(string); isString {
+			paths, ok = []string{path}, true
+		}
+		if !ok {
+			return fmt.Errorf("must be a file or a list of files")
+		}
+		var holidays []holidayRange
+		for _, path := range paths {
+			if !filepath.IsAbs(path) && !strings.HasPrefix(path, "~/") {
+				path = filepath.Join(cfg.dir, path)
+			}
+			ranges, err := readHolidayFile(path)
+			if err != nil {
+				return err
+			}
+			holidays = append(holidays, ranges...)
+		}
+		cfg.Holidays[key] = paths
+		cfg.holidays[key] = holidays
+		return nil
+	},
+}
+
+// isTimeLayout reports whether 'layout' is a Go time layout, i.e. it has an element of the
+// reference time: two instants differing in every element then format differently.
+func isTimeLayout(layout string) bool {
+	a := time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	b := time.Date(2017, 11, 28, 9, 37, 48, 123456789, time.UTC)
+	return a.Format(layout) != b.Format(layout)
+}
+
+// validatePlaceKey checks the key of a section keyed by places: 'default' or a place
+// resolved with the configuration 'cfg' being loaded.
+func validatePlaceKey(cfg Config, key string) error {
+	if key == "default" {
+		return nil
+	}
+	_, err := NewResolver(cfg).resolveBuiltinPlace(key)
+	return err
+}
+
+// config is the configuration in use, set by LoadConfig.
+var config = defaultConfig()
+
+// defaultConfig returns the configuration used when nothing is configured.
+func defaultConfig() Config {
+	return Config{Clock: 12, Output: "table", Abbreviations: map[string]string{}, Aliases: map[string][]string{},
+		Hours: map[string]workingHours{}, Weekends: map[string][]time.Weekday{}, Holidays: map[string][]string{},
+		holidays: map[string][]holidayRange{}}
+}
+
+// timeLayout returns the layout of the Date/Time column.
+func (cfg Config) timeLayout() string {
+	switch {
+	case cfg.Format != "":
+		return cfg.Format
+	case cfg.Clock == 24:
+		return "Mon, 02 Jan 2006 15:04:05"
+	default:
+		return "Mon, 02 Jan 2006 03:04:05 PM"
+	}
+}
+
+// shortTimeLayout returns the layout used where seconds would only be noise.
+func (cfg Config) shortTimeLayout() string {
+	switch {
+	case cfg.Format != "":
+		return cfg.Format
+	case cfg.Clock == 24:
+		return "Mon, 02 Jan 2006 15:04"
+	default:
+		return "Mon, 02 Jan 2006 03:04 PM"
+	}
+}
+
+// clockLayout returns the layout of a compact weekday and time, e.g. in the timezone picker.
+func (cfg Config) clockLayout() string {
+	if cfg.Clock == 24 {
+		return "Mon 15:04"
+	}
+	return "Mon 03:04 PM"
+}
+
+// hourLayout returns the layout of a bare time of day, e.g. in the cells of the calendar.
+func (cfg Config) hourLayout() string {
+	if cfg.Clock == 24 {
+		return "15:04"
+	}
+	return "03:04PM"
+}
+
+// configPath returns the path of the configuration file: $KTZ_CONFIG if set,
+// otherwise config.toml in $XDG_CONFIG_HOME/ktz or ~/.config/ktz.
+func configPath() (string, error) {
+	if path := os.Getenv("KTZ_CONFIG"); path != "" {
+		return path, nil
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "ktz", "config.toml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "ktz", "config.toml"), nil
+}
+
+// readConfigFile reads the configuration file at 'path'. A missing file is empty.
+func readConfigFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	return string(content), err
+}
+
+// readConfigEntries reads the entries of the configuration file at 'path'.
+// A missing file has no entries.
+func readConfigEntries(path string) ([]tomlEntry, error) {
+	content, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := parseTOML(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s:%v", path, strings.TrimPrefix(err.Error(), "line "))
+	}
+	return entries, nil
+}
+
+// applyConfigEntry validates a single entry and stores it in 'cfg'.
+func applyConfigEntry(cfg *Config, entry tomlEntry) error {
+	switch len(entry.path) {
+	case 1:
+		if setting, ok := configSettings[entry.path[0]]; ok {
+			return setting.apply(cfg, entry.value)
+		}
+	case 2:
+		if applySection, ok := configSections[entry.path[0]]; ok {
+			return applySection(cfg, entry.path[1], entry.value)
+		}
+	}
+	return fmt.Errorf("unknown key")
+}
+
+// loadConfig builds the configuration from the defaults, the entries of the
+// configuration file at 'path' and the environment variables. Relative holiday files
+// are in the directory of 'path'.
+func loadConfig(path string, entries []tomlEntry) (Config, error) {
+	cfg := defaultConfig()
+	cfg.dir = filepath.Dir(path)
+	// the home is applied last, as it may name an alias of the [aliases] section
+	isHome := func(entry tomlEntry) bool { return joinKeyPath(entry.path) == "home" }
+	for _, home := range []bool{false, true} {
+		for _, entry := range entries {
+			if isHome(entry) != home {
+				continue
+			}
+			if err := applyConfigEntry(&cfg, entry); err != nil {
+				return cfg, &ConfigError{Source: path, Line: entry.line, Key: joinKeyPath(entry.path), Reason: err.Error()}
+			}
+		}
+	}
+	for _, key := range sortedConfigKeys() {
+		setting := configSettings[key]
+		if value, ok := os.LookupEnv(setting.env); ok {
+			if err := setting.apply(&cfg, value); err != nil {
+				return cfg, &ConfigError{Source: setting.env, Key: key, Reason: err.Error()}
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// LoadConfig loads the configuration file and the KTZ_* environment variables.
+// It returns an error pointing to the offending key if a value is invalid.
+func LoadConfig() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	entries, err := readConfigEntries(path)
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(path, entries)
+	if err != nil {
+		return err
+	}
+	config = cfg
+	return nil
+}
+
+// ConfigGet prints the value in use for the given key.
+func ConfigGet(key string) error {
+	path, err := splitKeyPath(key)
+	if err != nil {
+		return err
+	}
+	for _, entry := range configEntries(config) {
+		if joinKeyPath(entry.path) == joinKeyPath(path) {
+			fmt.Println(formatConfigValue(entry.value))
+			return nil
+		}
+	}
+	if _, ok := configSections[path[0]]; ok && len(path) == 2 {
+		return fmt.Errorf("'%s' is not set", key)
+	}
+	return fmt.Errorf("Unknown configuration key '%s'", key)
+}
+
+// ConfigSet validates the value of the given key and writes it to the configuration file.
+// Several values are stored as a list.
+func ConfigSet(key string, values []string) error {
+	keyPath, err := splitKeyPath(key)
+	if err != nil {
+		return err
+	}
+	if keyPath[0] == "holidays" {
+		// the files are named relative to the current directory, the configuration file is
+		// read from any directory
+		values = slices.Clone(values)
+		for i, path := range values {
+			if !filepath.IsAbs(path) && !strings.HasPrefix(path, "~/") {
+				if values[i], err = filepath.Abs(path); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	var value any = values
+	if len(values) == 1 {
+		value = values[0]
+		if n, err := strconv.ParseInt(values[0], 10, 64); err == nil {
+			value = n
+		}
+	}
+	return updateConfigFile(keyPath, value)
+}
+
+// ConfigList prints every configuration value in use, including the defaults.
+func ConfigList() {
+	for _, entry := range configEntries(config) {
+		fmt.Printf("%s = %s\n", joinKeyPath(entry.path), formatConfigValue(entry.value))
+	}
+}
+
+// updateConfigFile sets the value of the key at 'keyPath' in the configuration file, or
+// removes the key if 'value' is nil, validates the result and writes it back. Only the
+// lines of that key change, so the comments and layout of the file are kept.
+func updateConfigFile(keyPath []string, value any) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	content, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+	if content, err = setTOMLValue(content, keyPath, value); err != nil {
+		return fmt.Errorf("%s:%v", path, strings.TrimPrefix(err.Error(), "line "))
+	}
+	entries, err := parseTOML(content)
+	if err != nil {
+		return fmt.Errorf("%s:%v", path, strings.TrimPrefix(err.Error(), "line "))
+	}
+	cfg, err := loadConfig(path, entries)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return err
+	}
+	config = cfg
+	return nil
+}
+
+// configEntries returns the values of 'cfg' as entries, sorted by key.
+func configEntries(cfg Config) []tomlEntry {
+	var entries []tomlEntry
+	for _, key := range sortedConfigKeys() {
+		entries = append(entries, tomlEntry{path: []string{key}, value: configSettings[key].get(cfg)})
+	}
+	for _, abbreviation := range sortedKeys(cfg.Abbreviations) {
+		entries = append(entries, tomlEntry{path: []string{"abbreviations", abbreviation}, value: cfg.Abbreviations[abbreviation]})
+	}
+	for _, alias := range sortedKeys(cfg.Aliases) {
+		entries = append(entries, tomlEntry{path: []string{"aliases", alias}, value: cfg.Aliases[alias]})
+	}
+	for _, place := range sortedKeys(cfg.Hours) {
+		entries = append(entries, tomlEntry{path: []string{"hours", place}, value: cfg.Hours[place].String()})
+	}
+	for _, place := range sortedKeys(cfg.Weekends) {
+		entries = append(entries, tomlEntry{path: []string{"weekends", place}, value: formatWeekdays(cfg.Weekends[place])})
+	}
+	for _, code := range sortedKeys(cfg.Holidays) {
+		entries = append(entries, tomlEntry{path: []string{"holidays", code}, value: cfg.Holidays[code]})
+	}
+	return entries
+}
+
+// sortedConfigKeys returns the top-level configuration keys in alphabetical order.
+func sortedConfigKeys() []string {
+	return sortedKeys(configSettings)
+}
+
+// sortedKeys returns the keys of 'm' in alphabetical order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// formatConfigValue formats a configuration value for printing.
+func formatConfigValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return formatTOMLValue(value)
+}
+
+// stringValue returns 'value' as a string; integers are accepted as written.
+func stringValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	}
+	return "", fmt.Errorf("must be a string")
+}
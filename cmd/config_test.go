@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseTOML(t *testing.T) {
+	doc := `# ktz defaults
+clock = 24 # hours
+format = "Mon 15:04"
+
+[abbreviations]
+IST = 'Asia/Kolkata'
+
+[hours."Asia/Kathmandu"]
+days = ["Sun", "Mon",
+        "Tue"]
+`
+	entries, err := parseTOML(doc)
+	if err != nil {
+		t.Fatalf("parseTOML returned error %v", err)
+	}
+	want := []struct {
+		key   string
+		value string
+		line  int
+	}{
+		{"clock", "24", 2},
+		{"format", `"Mon 15:04"`, 3},
+		{"abbreviations.IST", `"Asia/Kolkata"`, 6},
+		{`hours."Asia/Kathmandu".days`, `["Sun", "Mon", "Tue"]`, 9},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("parseTOML returned %d entries, want %d", len(entries), len(want))
+	}
+	for i, entry := range entries {
+		if key := joinKeyPath(entry.path); key != want[i].key {
+			t.Fatalf("entry %d key = %v, want %v", i, key, want[i].key)
+		}
+		if value := formatTOMLValue(entry.value); value != want[i].value {
+			t.Fatalf("entry %d value = %v, want %v", i, value, want[i].value)
+		}
+		if entry.line != want[i].line {
+			t.Fatalf("entry %d line = %v, want %v", i, entry.line, want[i].line)
+		}
+	}
+
+	// encoding and parsing again gives the same entries
+	again, err := parseTOML(encodeTOML(entries))
+	if err != nil || len(again) != len(entries) {
+		t.Fatalf("parseTOML(encodeTOML()) = %v, %v", again, err)
+	}
+}
+
+func TestParseTOMLErrors(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{given: "clock 24", want: "line 1: expected `key = value`"},
+		{given: "\nformat = \"abc", want: "line 2: format: unterminated string"},
+		{given: "[hours", want: "line 1: invalid table header \"[hours\""},
+		{given: "home = ", want: "line 1: home: missing value"},
+	}
+	for _, test := range tests {
+		_, err := parseTOML(test.given)
+		if err == nil || err.Error() != test.want {
+			t.Fatalf("parseTOML(%q) returned error '%v', want '%v'", test.given, err, test.want)
+		}
+	}
+}
+
+func TestSetTOMLValue(t *testing.T) {
+	doc := `# ktz defaults
+clock = 12 # inline
+
+# my aliases
+[aliases]
+apac = ["Tokyo",
+        "Sydney"] # the team
+`
+	tests := []struct {
+		name  string
+		doc   string
+		path  []string
+		value any
+		want  string
+	}{
+		{"existing key keeps its comment", doc, []string{"clock"}, int64(24),
+			"# ktz defaults\nclock = 24 # inline\n\n# my aliases\n[aliases]\napac = [\"Tokyo\",\n        \"Sydney\"] # the team\n"},
+		{"array over several lines", doc, []string{"aliases", "apac"}, []string{"Tokyo"},
+			"# ktz defaults\nclock = 12 # inline\n\n# my aliases\n[aliases]\napac = [\"Tokyo\"] # the team\n"},
+		{"new key of a table", doc, []string{"aliases", "office"}, "Europe/Berlin",
+			"# ktz defaults\nclock = 12 # inline\n\n# my aliases\n[aliases]\napac = [\"Tokyo\",\n        \"Sydney\"] # the team\noffice = \"Europe/Berlin\"\n"},
+		{"new top-level key", doc, []string{"home"}, "Kathmandu",
+			"# ktz defaults\nclock = 12 # inline\nhome = \"Kathmandu\"\n\n# my aliases\n[aliases]\napac = [\"Tokyo\",\n        \"Sydney\"] # the team\n"},
+		{"first top-level key", "# aliases\n[aliases]\nhq = \"UTC\"\n", []string{"clock"}, int64(24),
+			"clock = 24\n\n# aliases\n[aliases]\nhq = \"UTC\"\n"},
+		{"new table", doc, []string{"abbreviations", "IST"}, "Asia/Kolkata",
+			doc + "\n[abbreviations]\nIST = \"Asia/Kolkata\"\n"},
+		{"empty table", "[aliases]\n\n[hours]\n", []string{"aliases", "hq"}, "UTC",
+			"[aliases]\nhq = \"UTC\"\n\n[hours]\n"},
+		{"removed key", doc, []string{"aliases", "apac"}, nil,
+			"# ktz defaults\nclock = 12 # inline\n\n# my aliases\n[aliases]\n"},
+		{"empty document", "", []string{"clock"}, int64(24), "clock = 24\n"},
+	}
+	for _, test := range tests {
+		got, err := setTOMLValue(test.doc, test.path, test.value)
+		if err != nil || got != test.want {
+			t.Fatalf("%v: `setTOMLValue` = %q, %v, want %q", test.name, got, err, test.want)
+		}
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	entries, _ := parseTOML("clock = 24\noutput = \"plain\"\n[abbreviations]\nist = \"Asia/Kolkata\"\n")
+	t.Setenv("KTZ_OUTPUT", "json")
+	cfg, err := loadConfig("config.toml", entries)
+	if err != nil {
+		t.Fatalf("loadConfig returned error %v", err)
+	}
+	if cfg.Clock != 24 || cfg.Output != "json" || cfg.Abbreviations["IST"] != "Asia/Kolkata" {
+		t.Fatalf("loadConfig = %+v, want clock 24, output json and IST = Asia/Kolkata", cfg)
+	}
+	if cfg.timeLayout() != "Mon, 02 Jan 2006 15:04:05" {
+		t.Fatalf("timeLayout() = %v, want a 24 hour layout", cfg.timeLayout())
+	}
+}
+
+func TestLoadConfigFormat(t *testing.T) {
+	for _, format := range []string{"Mon, 02 Jan 2006 15:04", "2006-01-02 15:04", "3:04PM", "Monday"} {
+		entries, _ := parseTOML(fmt.Sprintf("format = %q", format))
+		cfg, err := loadConfig("config.toml", entries)
+		if err != nil || cfg.timeLayout() != format {
+			t.Fatalf("loadConfig(format = %q) = %v, %v, want the layout accepted", format, cfg.timeLayout(), err)
+		}
+	}
+	t.Setenv("KTZ_FORMAT", "2006-01-02 15:04")
+	if cfg, err := loadConfig("config.toml", nil); err != nil || cfg.Format != "2006-01-02 15:04" {
+		t.Fatalf("loadConfig with KTZ_FORMAT = %q, %v, want the layout accepted", cfg.Format, err)
+	}
+}
+
+func TestLoadConfigAliasHome(t *testing.T) {
+	// the home comes before the alias it names, as `config set home` writes it
+	entries, _ := parseTOML("home = \"office\"\n[aliases]\noffice = \"Europe/Berlin\"\n")
+	cfg, err := loadConfig("config.toml", entries)
+	if err != nil || cfg.Home != "office" {
+		t.Fatalf("loadConfig with an alias as home = %q, %v, want the alias accepted", cfg.Home, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("KTZ_CONFIG", path)
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	if err := SetAlias("office", []string{"Europe/Berlin"}); err != nil {
+		t.Fatalf("SetAlias(office) returned error %v", err)
+	}
+	if err := ConfigSet("home", []string{"office"}); err != nil {
+		t.Fatalf("ConfigSet(home, office) returned error %v", err)
+	}
+	if err := LoadConfig(); err != nil || config.Home != "office" {
+		t.Fatalf("LoadConfig after setting an alias as home = %q, %v", config.Home, err)
+	}
+	if home, err := DefaultResolver().homeLocation(config.Home); err != nil || home.String() != "Europe/Berlin" {
+		t.Fatalf("homeLocation(office) = %v, %v, want Europe/Berlin", home, err)
+	}
+}
+
+func TestLoadConfigErrors(t *testing.T) {
+	tests := []struct {
+		given string
+		env   string
+		want  string
+	}{
+		{given: "clock = 24\nclock = 13", want: "config.toml:2: clock: must be 12 or 24"},
+		{given: "colour = \"red\"", want: "config.toml:1: colour: unknown key"},
+		{given: "[abbreviations]\nXYZ = \"Mars/Olympus\"", want: "config.toml:2: abbreviations.XYZ: unknown timezone \"Mars/Olympus\""},
+		{given: "format = \"hello\"", want: "config.toml:1: format: \"hello\" is not a Go time layout like \"Mon, 02 Jan 2006 15:04\""},
+		{given: "", env: "xml", want: "KTZ_OUTPUT: output: must be one of table, plain or json"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			if test.env != "" {
+				t.Setenv("KTZ_OUTPUT", test.env)
+			}
+			entries, _ := parseTOML(test.given)
+			_, err := loadConfig("config.toml", entries)
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) || err.Error() != test.want {
+				t.Fatalf("loadConfig returned error '%v', want '%v'", err, test.want)
+			}
+		})
+	}
+}
+
+func TestConfigSet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	t.Setenv("KTZ_CONFIG", path)
+	defer func(cfg Config) { config = cfg }(config)
+
+	if err := ConfigSet("clock", []string{"24"}); err != nil {
+		t.Fatalf("ConfigSet(clock, 24) returned error %v", err)
+	}
+	if err := ConfigSet("abbreviations.IST", []string{"Asia/Kolkata"}); err != nil {
+		t.Fatalf("ConfigSet(abbreviations.IST) returned error %v", err)
+	}
+	if err := ConfigSet("clock", []string{"13"}); err == nil {
+		t.Fatalf("ConfigSet(clock, 13) returned no error")
+	}
+	content, _ := os.ReadFile(path)
+	want := "clock = 24\n\n[abbreviations]\nIST = \"Asia/Kolkata\"\n"
+	if string(content) != want {
+		t.Fatalf("config file = %q, want %q", content, want)
+	}
+	if config.Clock != 24 || !strings.Contains(config.timeLayout(), "15:04") {
+		t.Fatalf("config not updated after ConfigSet: %+v", config)
+	}
+
+	// the user's comments and layout are kept
+	os.WriteFile(path, []byte("# my comment\nclock = 12 # inline\n"), 0o644)
+	if err := ConfigSet("clock", []string{"24"}); err != nil {
+		t.Fatalf("ConfigSet(clock, 24) returned error %v", err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != "# my comment\nclock = 24 # inline\n" {
+		t.Fatalf("config file = %q, want the comments kept", content)
+	}
+}
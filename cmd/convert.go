@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// ConvertOptions holds the options of the `convert` command.
+type ConvertOptions struct {
+	ICS      bool          // Print an iCalendar event instead of a table
+	Title    string        // The summary of the iCalendar event
+	Duration time.Duration // The duration of the iCalendar event
+	Daylight bool          // Show whether it's day or night in each place
+}
+
+// convertLocations returns the places with the instant 't' converted to their timezones.
+// Without places, 't' is converted to the home zone.
+func (r *Resolver) convertLocations(t time.Time, places []string) ([]locationInfo, error) {
+	if len(places) == 0 {
+		home := r.home
+		if home == "" {
+			hours, weekend := r.locationSchedule(locationInfo{})
+			return []locationInfo{{place: "Local", timezone: time.Local.String(), formattedTime: t.Local().Format(r.shortLayout),
+				difference: formatOffsetDifference(offsetDifference(t, t.Local())), day: describeDay(t, t.Local()),
+				status: statusAt(t.Local(), hours, weekend)}}, nil
+		}
+		places = []string{home}
+	}
+	locations, err := r.resolvePlaces(places)
+	if err != nil {
+		return nil, err
+	}
+	for i := range locations {
+		loc, err := loadLocation(locations[i].timezone)
+		if err != nil {
+			return nil, err
+		}
+		locations[i].formattedTime = t.In(loc).Format(r.shortLayout)
+		locations[i].difference = formatOffsetDifference(offsetDifference(t, t.In(loc)))
+		locations[i].day = describeDay(t, t.In(loc))
+		if locations[i].status, err = r.locationStatus(locations[i], t); err != nil {
+			return nil, err
+		}
+		locations[i].holiday = r.holidayOn(locations[i], t.In(loc))
+	}
+	return locations, nil
+}
+
+// ConvertTime prints a time like '2026-11-03 09:30 Kathmandu' converted to each of the places,
+// or, with 'opts.ICS', an iCalendar event at that time listing the converted times.
+func ConvertTime(r *Resolver, at string, places []string, opts ConvertOptions) {
+	m, err := r.parseMoment(at)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	t := m.instant(time.Now())
+	locations, err := r.convertLocations(t, places)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	if opts.Daylight {
+		for i := range locations {
+			if locations[i].daylight, err = daylightAt(locations[i], t); err != nil {
+				fmt.Printf("\nError: %v\n", err)
+				return
+			}
+		}
+	}
+	if !opts.ICS {
+		renderLocationsTable(fmt.Sprintf("%v (%v) is:", t.Format(r.shortLayout), m.loc), locations)
+		return
+	}
+	var description strings.Builder
+	for _, location := range locations {
+		fmt.Fprintf(&description, "%v: %v\n", location.place, location.formattedTime)
+	}
+	event := icsEvent{
+		summary:     opts.Title,
+		description: strings.TrimSuffix(description.String(), "\n"),
+		start:       t,
+		duration:    opts.Duration,
+	}
+	if err := writeICSEvent(os.Stdout, event, time.Now()); err != nil {
+		fmt.Printf("\nError: %v\n", err)
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// canonicalCountry returns the name of a country in tzdata.CountryToIanaTimezone for any of
+// its names in tzdata.CountryAliases (ignoring case, spaces and punctuation), e.g. 'Netherlands'
+// for 'holland'. Other names are returned as given, without surrounding spaces.
+func canonicalCountry(name string) string {
+	name = strings.TrimSpace(name)
+	if _, ok := tzdata.CountryToIanaTimezone[name]; ok {
+		return name
+	}
+	if country, ok := tzdata.CountryAliases[name]; ok {
+		return country
+	}
+	cleaned := cleanWord(name)
+	for alias, country := range tzdata.CountryAliases {
+		if cleanWord(alias) == cleaned {
+			return country
+		}
+	}
+	return name
+}
+
+// primaryZone returns the timezone a country is shown in when no other is picked: its
+// tzdata.CountryPrimaryZone if it has several, otherwise its only one.
+func primaryZone(country string) string {
+	if tz, ok := tzdata.CountryPrimaryZone[country]; ok {
+		return tz
+	}
+	if zones := tzdata.CountryToIanaTimezone[country]; len(zones) > 0 {
+		return zones[0]
+	}
+	return ""
+}
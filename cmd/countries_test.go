@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+func TestCountryAliasesAreCountries(t *testing.T) {
+	for alias, country := range tzdata.CountryAliases {
+		if _, ok := tzdata.CountryToIanaTimezone[country]; !ok {
+			t.Fatalf("tzdata.CountryAliases[%q] = %q, which isn't in tzdata.CountryToIanaTimezone", alias, country)
+		}
+	}
+}
+
+func TestCountryOfCode(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{"RU", "Russia"},
+		{"RUS", "Russia"},
+		{"cz", "Czech Republic"},
+		{"CIV", "Ivory Coast"},
+		{"SY", "Syria"},
+		{"NLD", "Netherlands"},
+		{"NP", "Nepal"},
+		{"XX", ""},
+	}
+	for _, test := range tests {
+		if got := countryOfCode(test.given); got != test.want {
+			t.Fatalf("`countryOfCode(%v)` = %q, want %q", test.given, got, test.want)
+		}
+	}
+}
+
+func TestCountryAliases(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{"America", "United States of America"},
+		{"UK", "United Kingdom"},
+		{"Britain", "United Kingdom"},
+		{"England", "United Kingdom"},
+		{"Holland", "Netherlands"},
+		{"Czech Republic", "Czech Republic"},
+		{"czechia", "Czech Republic"},
+		{"Ivory Coast", "Ivory Coast"},
+		{"Cote d'Ivoire", "Ivory Coast"},
+		{"Burma", "Myanmar"},
+		{"South Korea", "South Korea"},
+		{"Korea", "South Korea"},
+	}
+	r := NewResolver(defaultConfig())
+	for _, test := range tests {
+		got, err := r.getMatchingLocation("", test.given)
+		if err != nil || !EqualSlices(got, []string{test.want}) {
+			t.Fatalf("`getMatchingLocation(\"\", %v)` = %v, %v, want %v", test.given, got, err, test.want)
+		}
+		if got := canonicalCountry(test.given); got != test.want {
+			t.Fatalf("`canonicalCountry(%v)` = %q, want %q", test.given, got, test.want)
+		}
+	}
+	// an alias shows up under the canonical name, once
+	if got, _ := r.getMatchingLocation("", "Czech"); !EqualSlices(got, []string{"Czech Republic"}) {
+		t.Fatalf("`getMatchingLocation(\"\", Czech)` = %v, want [Czech Republic]", got)
+	}
+	if got := formatResults(r.search("holland", 1)); !EqualSlices(got, []string{"Netherlands (country)"}) {
+		t.Fatalf("`search(holland, 1)` = %q, want Netherlands (country)", got)
+	}
+}
+
+func TestCountryZonesExist(t *testing.T) {
+	for country, zones := range tzdata.CountryToIanaTimezone {
+		for _, tz := range zones {
+			if _, err := loadLocation(tz); err != nil {
+				t.Errorf("tzdata.CountryToIanaTimezone[%q] has the unknown timezone %q", country, tz)
+			}
+		}
+	}
+}
+
+func TestCountryPrimaryZone(t *testing.T) {
+	for country, zones := range tzdata.CountryToIanaTimezone {
+		tz, ok := tzdata.CountryPrimaryZone[country]
+		if len(zones) > 1 && !ok {
+			t.Errorf("tzdata.CountryPrimaryZone has no timezone for %q, which has %d", country, len(zones))
+		}
+		if ok && !slices.Contains(zones, tz) {
+			t.Errorf("tzdata.CountryPrimaryZone[%q] = %q, which isn't one of its timezones", country, tz)
+		}
+	}
+	for country := range tzdata.CountryPrimaryZone {
+		if len(tzdata.CountryToIanaTimezone[country]) < 2 {
+			t.Errorf("tzdata.CountryPrimaryZone has %q, which hasn't several timezones", country)
+		}
+	}
+}
+
+func TestPrimaryZoneWithoutTerminal(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	config.Output = "plain"
+	r := NewResolver(config)
+	tests := []struct {
+		given []string
+		want  string
+	}{
+		{[]string{"Argentina"}, "America/Argentina/Buenos_Aires"},
+		{[]string{"United States of America"}, "America/New_York"},
+		{[]string{"Nepal"}, "Asia/Kathmandu"},
+		{[]string{"London", "Los Angeles"}, "Europe/London"},
+	}
+	for _, test := range tests {
+		location, err := r.getDataFromLocation(test.given)
+		if err != nil || location.timezone != test.want {
+			t.Fatalf("`getDataFromLocation(%v)` = %v, %v, want %v", test.given, location.timezone, err, test.want)
+		}
+	}
+	if location, err := r.resolvePlace("Australia"); err != nil || location.timezone != "Australia/Sydney" {
+		t.Fatalf("`resolvePlace(Australia)` = %v, %v, want Australia/Sydney", location.timezone, err)
+	}
+}
+
+func TestPickerPreselectsPrimaryZone(t *testing.T) {
+	m := initialModel(listView)
+	m.list.SetItems(newZoneItems("Russia", tzdata.CountryToIanaTimezone["Russia"], time.Now()))
+	selectZone(&m.list, primaryZone("Russia"))
+	if zone, ok := m.list.SelectedItem().(zoneItem); !ok || zone.zone != "Europe/Moscow" {
+		t.Fatalf("the picker of Russia selected %v, want Europe/Moscow", m.list.SelectedItem())
+	}
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// solarDay holds the sun times of a place on a day, in the place's location.
+type solarDay struct {
+	sunrise, sunset time.Time // Zero during a polar day or night
+	noon            time.Time
+	length          time.Duration
+	polar           string // "polar day" or "polar night" when the sun doesn't rise or set
+}
+
+// sunZenith is the zenith of the sun at sunrise and sunset in degrees, accounting for
+// atmospheric refraction and the size of the solar disk.
+const sunZenith = 90.833
+
+// sunTimes returns the sun times of the day of 'date' in 'loc' at the given coordinates,
+// using the NOAA approximation of the solar position, accurate to about a minute.
+func sunTimes(date time.Time, loc *time.Location, c tzdata.Coordinates) solarDay {
+	local := date.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	// the fractional year at local solar noon, in radians
+	days := 365.0
+	if daysIn(local.Year(), time.February) == 29 {
+		days = 366
+	}
+	gamma := 2 * math.Pi / days * (float64(local.YearDay()-1) + (12-c.Longitude/15)/24)
+
+	eqTime := 229.18 * (0.000075 + 0.001868*math.Cos(gamma) - 0.032077*math.Sin(gamma) -
+		0.014615*math.Cos(2*gamma) - 0.040849*math.Sin(2*gamma))
+	declination := 0.006918 - 0.399912*math.Cos(gamma) + 0.070257*math.Sin(gamma) -
+		0.006758*math.Cos(2*gamma) + 0.000907*math.Sin(2*gamma) -
+		0.002697*math.Cos(3*gamma) + 0.00148*math.Sin(3*gamma)
+
+	// minutes after midnight UTC of the local date
+	at := func(minutes float64) time.Time {
+		return midnight.Add(time.Duration(minutes * float64(time.Minute))).Round(time.Second).In(loc)
+	}
+	day := solarDay{noon: at(720 - 4*c.Longitude - eqTime)}
+
+	latitude := c.Latitude * math.Pi / 180
+	cosHourAngle := math.Cos(sunZenith*math.Pi/180)/(math.Cos(latitude)*math.Cos(declination)) -
+		math.Tan(latitude)*math.Tan(declination)
+	switch {
+	case cosHourAngle > 1:
+		day.polar = "polar night"
+		return day
+	case cosHourAngle < -1:
+		day.polar = "polar day"
+		day.length = 24 * time.Hour
+		return day
+	}
+	hourAngle := math.Acos(cosHourAngle) * 180 / math.Pi
+	day.sunrise = at(720 - 4*(c.Longitude+hourAngle) - eqTime)
+	day.sunset = at(720 - 4*(c.Longitude-hourAngle) - eqTime)
+	day.length = day.sunset.Sub(day.sunrise)
+	return day
+}
+
+// locationCoordinates returns the coordinates of a location: those of its city, or of
+// the city representing its timezone. It returns false if none are known.
+func locationCoordinates(location locationInfo) (tzdata.Coordinates, bool) {
+	if c, ok := tzdata.CityCoordinates[location.city]; ok {
+		return c, true
+	}
+	c, ok := tzdata.CityCoordinates[representativeCity(location.timezone)]
+	return c, ok
+}
+
+// locationSun returns the sun times of a location on the day of the instant 't'.
+func locationSun(location locationInfo, t time.Time) (*solarDay, error) {
+	c, ok := locationCoordinates(location)
+	if !ok {
+		return nil, nil
+	}
+	loc, err := loadLocation(location.timezone)
+	if err != nil {
+		return nil, err
+	}
+	day := sunTimes(t, loc, c)
+	return &day, nil
+}
+
+// formatSunTime formats a sunrise or sunset, or the polar day or night without one.
+func (d solarDay) formatSunTime(t time.Time) string {
+	if t.IsZero() {
+		return d.polar
+	}
+	return t.Format(config.hourLayout())
+}
+
+// formatDayLength formats the day length like "11h 36m".
+func (d solarDay) formatDayLength() string {
+	minutes := int(d.length.Round(time.Minute).Minutes())
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+// daylightAt describes the daylight of a location at the instant 't', e.g. "day, sunset 05:48PM"
+// or "night, sunrise 06:12AM". It returns "" if the coordinates of the location are unknown.
+func daylightAt(location locationInfo, t time.Time) (string, error) {
+	day, err := locationSun(location, t)
+	if err != nil || day == nil {
+		return "", err
+	}
+	switch {
+	case day.polar != "":
+		return day.polar, nil
+	case t.Before(day.sunrise):
+		return "night, sunrise " + day.formatSunTime(day.sunrise), nil
+	case t.Before(day.sunset):
+		return "day, sunset " + day.formatSunTime(day.sunset), nil
+	}
+	next, err := locationSun(location, day.noon.AddDate(0, 0, 1))
+	if err != nil {
+		return "", err
+	}
+	if next.polar != "" {
+		return "night, " + next.polar + " tomorrow", nil
+	}
+	return "night, sunrise " + next.formatSunTime(next.sunrise), nil
+}
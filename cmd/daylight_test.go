@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+func TestSunTimes(t *testing.T) {
+	tests := []struct {
+		city            string
+		date            string
+		sunrise, sunset string // local times from published almanacs
+	}{
+		{"London", "2026-06-21", "04:43", "21:21"},
+		{"London", "2026-12-21", "08:04", "15:53"},
+		{"Sydney", "2026-06-21", "07:00", "16:54"},
+		{"Kathmandu", "2026-10-19", "06:04", "17:31"},
+	}
+	for _, test := range tests {
+		location := locationInfo{city: test.city, timezone: tzdata.CityToIanaTimezone[test.city]["tz"]}
+		loc, _ := loadLocation(location.timezone)
+		date, _ := time.ParseInLocation("2006-01-02 15:04", test.date+" 12:00", loc)
+		day, err := locationSun(location, date)
+		if err != nil || day == nil {
+			t.Fatalf("`locationSun(%v)` = %v, %v", test.city, day, err)
+		}
+		for _, check := range []struct {
+			got  time.Time
+			want string
+		}{{day.sunrise, test.sunrise}, {day.sunset, test.sunset}} {
+			want, _ := time.ParseInLocation("2006-01-02 15:04", test.date+" "+check.want, loc)
+			if diff := check.got.Sub(want).Abs(); diff > 3*time.Minute {
+				t.Fatalf("%v on %v: got %v, want %v", test.city, test.date, check.got.Format("15:04"), check.want)
+			}
+		}
+		if !day.noon.After(day.sunrise) || !day.noon.Before(day.sunset) || day.length != day.sunset.Sub(day.sunrise) {
+			t.Fatalf("%v on %v: inconsistent sun times %+v", test.city, test.date, day)
+		}
+	}
+}
+
+func TestSunTimesPolar(t *testing.T) {
+	longyearbyen := tzdata.CityCoordinates["Longyearbyen"]
+	loc, _ := loadLocation("Arctic/Longyearbyen")
+	if day := sunTimes(time.Date(2026, 6, 21, 12, 0, 0, 0, loc), loc, longyearbyen); day.polar != "polar day" || day.length != 24*time.Hour {
+		t.Fatalf("Longyearbyen in June = %+v, want a polar day", day)
+	}
+	if day := sunTimes(time.Date(2026, 12, 21, 12, 0, 0, 0, loc), loc, longyearbyen); day.polar != "polar night" || !day.sunrise.IsZero() {
+		t.Fatalf("Longyearbyen in December = %+v, want a polar night", day)
+	}
+}
+
+func TestDaylightAt(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	config.Clock = 24
+
+	london := locationInfo{city: "London", timezone: "Europe/London"}
+	loc, _ := loadLocation("Europe/London")
+	tests := []struct {
+		at   time.Time
+		want string
+	}{
+		{time.Date(2026, 6, 21, 3, 0, 0, 0, loc), "night, sunrise 04:42"},
+		{time.Date(2026, 6, 21, 13, 0, 0, 0, loc), "day, sunset 21:21"},
+		{time.Date(2026, 6, 21, 23, 0, 0, 0, loc), "night, sunrise 04:42"},
+	}
+	for _, test := range tests {
+		got, err := daylightAt(london, test.at)
+		if err != nil || got != test.want {
+			t.Fatalf("`daylightAt(London, %v)` = %q, %v, want %q", test.at, got, err, test.want)
+		}
+	}
+	// a zone without a known city has no daylight
+	if got, err := daylightAt(locationInfo{timezone: "EST5EDT"}, time.Now()); err != nil || got != "" {
+		t.Fatalf("`daylightAt(EST5EDT)` = %q, %v", got, err)
+	}
+}
+
+func TestCityCoordinates(t *testing.T) {
+	for city := range tzdata.CityToIanaTimezone {
+		c, ok := tzdata.CityCoordinates[city]
+		if !ok {
+			t.Fatalf("`tzdata.CityCoordinates` lacks %q", city)
+		}
+		if c.Latitude < -90 || c.Latitude > 90 || c.Longitude < -180 || c.Longitude > 180 {
+			t.Fatalf("`tzdata.CityCoordinates[%q]` = %v", city, c)
+		}
+	}
+	if len(tzdata.CityCoordinates) != len(tzdata.CityToIanaTimezone) {
+		t.Fatalf("`tzdata.CityCoordinates` has %d cities, want %d", len(tzdata.CityCoordinates), len(tzdata.CityToIanaTimezone))
+	}
+}
@@ -0,0 +1,400 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// exploreLevel is a level of the explorer, from the regions down to the cities of a zone.
+type exploreLevel int
+
+const (
+	regionLevel exploreLevel = iota
+	countryLevel
+	zoneLevel
+	cityLevel
+)
+
+// detailStyle is the style of the pane showing the selected entry in the explorer.
+var detailStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("240")).
+	Padding(0, 1).
+	MarginTop(1).
+	Width(64)
+
+// exploreKeys are the keys of the explorer shown in the help line besides the list's own.
+var exploreKeys = []key.Binding{
+	key.NewBinding(key.WithKeys("enter", "right"), key.WithHelp("enter", "open/choose")),
+	key.NewBinding(key.WithKeys("esc", "left"), key.WithHelp("esc", "back")),
+}
+
+// explorer is a bubbletea model to browse the built-in data level by level: regions, their
+// countries, the timezones of a country and the cities using a timezone, with the current
+// time, offset, DST status and next transition of the selected entry.
+type explorer struct {
+	levels   []list.Model // The lists from the regions down to the current level
+	path     []string     // The region, country and zone opened to reach the current level
+	deepest  exploreLevel // The level at which an entry is chosen rather than opened
+	selected locationInfo // The location chosen, returned by runExplorer
+	quitting bool
+	now      func() time.Time
+}
+
+// newExplorer returns an explorer at the list of regions.
+func newExplorer(now func() time.Time) explorer {
+	m := explorer{deepest: cityLevel, now: now}
+	m.push(exploreRegions())
+	return m
+}
+
+// newExploreList returns a list of the explorer with type-to-filter enabled.
+func newExploreList(title string, items []list.Item) list.Model {
+	const defaultWidth = 50
+	l := list.New(items, itemDelegate{fitWidth: true}, defaultWidth, listHeight)
+	l.Title = title
+	l.SetShowStatusBar(false)
+	l.SetFilteringEnabled(true)
+	l.Styles.Title = titleStyle
+	l.Styles.PaginationStyle = paginationStyle
+	l.Styles.HelpStyle = helpStyle
+	// left and right open and close levels instead of turning pages, and letters filter
+	// instead of moving, so only the arrows and page keys move
+	l.KeyMap.CursorUp = key.NewBinding(key.WithKeys("up"), key.WithHelp("↑", "up"))
+	l.KeyMap.CursorDown = key.NewBinding(key.WithKeys("down"), key.WithHelp("↓", "down"))
+	l.KeyMap.PrevPage = key.NewBinding(key.WithKeys("pgup"), key.WithHelp("pgup", "prev page"))
+	l.KeyMap.NextPage = key.NewBinding(key.WithKeys("pgdown"), key.WithHelp("pgdown", "next page"))
+	l.KeyMap.GoToStart = key.NewBinding(key.WithKeys("home"), key.WithHelp("home", "go to start"))
+	l.KeyMap.GoToEnd = key.NewBinding(key.WithKeys("end"), key.WithHelp("end", "go to end"))
+	l.KeyMap.Filter.SetHelp("a-z", "filter")
+	l.KeyMap.Quit.SetEnabled(false)
+	l.KeyMap.ShowFullHelp.SetEnabled(false)
+	l.KeyMap.CloseFullHelp.SetEnabled(false)
+	l.AdditionalShortHelpKeys = func() []key.Binding { return exploreKeys }
+	return l
+}
+
+// exploreCountries returns the countries of Alpha2ToCountry under their usual names, sorted.
+func exploreCountries() []string {
+	countries := map[string]bool{}
+	for _, name := range tzdata.Alpha2ToCountry {
+		if country := canonicalCountry(name); len(tzdata.CountryToIanaTimezone[country]) > 0 {
+			countries[country] = true
+		}
+	}
+	return sortedKeys(countries)
+}
+
+// exploreRegions returns the regions of the timezones of the countries, like Europe.
+func exploreRegions() []list.Item {
+	regions := map[string]bool{}
+	for _, country := range exploreCountries() {
+		for _, tz := range tzdata.CountryToIanaTimezone[country] {
+			regions[zoneRegion(tz)] = true
+		}
+	}
+	var items []list.Item
+	for _, region := range sortedKeys(regions) {
+		items = append(items, item(region))
+	}
+	return items
+}
+
+// regionCountries returns the countries with a timezone in 'region'.
+func regionCountries(region string) []string {
+	var countries []string
+	for _, country := range exploreCountries() {
+		if containsFunc(tzdata.CountryToIanaTimezone[country], func(tz string) bool { return zoneRegion(tz) == region }) {
+			countries = append(countries, country)
+		}
+	}
+	return countries
+}
+
+// regionZone returns the timezone of 'country' shown for 'region': its primary zone if it's
+// in the region, otherwise its first zone there.
+func regionZone(country, region string) string {
+	if primary := primaryZone(country); zoneRegion(primary) == region || region == "" {
+		return primary
+	}
+	for _, tz := range tzdata.CountryToIanaTimezone[country] {
+		if zoneRegion(tz) == region {
+			return tz
+		}
+	}
+	return primaryZone(country)
+}
+
+// zoneCities returns the known cities of 'country' using the timezone 'tz', sorted.
+func zoneCities(country, tz string) []string {
+	var cities []string
+	for _, city := range sortedKeys(tzdata.CityToIanaTimezone) {
+		val := tzdata.CityToIanaTimezone[city]
+		if val["tz"] == tz && canonicalCountry(val["country"]) == country {
+			cities = append(cities, city)
+		}
+	}
+	return cities
+}
+
+// level returns the level the explorer is at.
+func (m explorer) level() exploreLevel {
+	return exploreLevel(len(m.levels) - 1)
+}
+
+// push opens a level listing 'items' below the current one.
+func (m *explorer) push(items []list.Item) {
+	title := strings.Join(append([]string{"World"}, m.path...), " › ")
+	m.levels = append(m.levels, newExploreList(title, items))
+}
+
+// current returns the list of the current level.
+func (m *explorer) current() *list.Model {
+	return &m.levels[len(m.levels)-1]
+}
+
+// openRegion opens the countries of a region.
+func (m *explorer) openRegion(region string) {
+	m.path = append(m.path, region)
+	var items []list.Item
+	for _, country := range regionCountries(region) {
+		items = append(items, item(country))
+	}
+	m.push(items)
+}
+
+// openCountry opens the timezones of a country, sorted by offset, selecting the one of the
+// region it was opened from.
+func (m *explorer) openCountry(country string) {
+	region := m.path[0]
+	m.path = append(m.path, country)
+	m.push(newZoneItems(country, tzdata.CountryToIanaTimezone[country], m.now()))
+	selectZone(m.current(), regionZone(country, region))
+}
+
+// openZone opens the cities of the country using a timezone.
+func (m *explorer) openZone(tz string) {
+	var items []list.Item
+	for _, city := range zoneCities(m.path[1], tz) {
+		items = append(items, item(city))
+	}
+	m.path = append(m.path, tz)
+	m.push(items)
+}
+
+// openAt opens the explorer at the timezones of 'country', under the region of its primary zone.
+func (m *explorer) openAt(country string) {
+	region := zoneRegion(primaryZone(country))
+	selectItem(m.current(), region)
+	m.openRegion(region)
+	selectItem(m.current(), country)
+	m.openCountry(country)
+}
+
+// selectItem selects the item named 'name' in a list of items, if any.
+func selectItem(l *list.Model, name string) {
+	for i, listItem := range l.Items() {
+		if listItem == item(name) {
+			l.Select(i)
+			return
+		}
+	}
+}
+
+// open opens the selected entry, or chooses it at the deepest level or if it has nothing below.
+func (m explorer) open() (tea.Model, tea.Cmd) {
+	switch selected := m.current().SelectedItem().(type) {
+	case item:
+		switch m.level() {
+		case regionLevel:
+			m.openRegion(string(selected))
+		case countryLevel:
+			m.openCountry(string(selected))
+		case cityLevel:
+			val := tzdata.CityToIanaTimezone[string(selected)]
+			m.selected = locationInfo{city: string(selected), country: m.path[1], timezone: val["tz"]}
+			return m, tea.Quit
+		}
+	case zoneItem:
+		if m.deepest == zoneLevel || len(zoneCities(m.path[1], selected.zone)) == 0 {
+			m.selected = locationInfo{country: m.path[1], timezone: selected.zone}
+			return m, tea.Quit
+		}
+		m.openZone(selected.zone)
+	}
+	return m, nil
+}
+
+// back closes the current level, or quits at the regions.
+func (m explorer) back() (tea.Model, tea.Cmd) {
+	if m.level() == regionLevel {
+		m.quitting = true
+		return m, tea.Quit
+	}
+	m.levels = m.levels[:len(m.levels)-1]
+	m.path = m.path[:len(m.path)-1]
+	return m, nil
+}
+
+func (m explorer) Init() tea.Cmd {
+	return tick()
+}
+
+func (m explorer) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		for i := range m.levels {
+			m.levels[i].SetWidth(msg.Width / 2)
+		}
+		return m, nil
+	case tickMsg:
+		// refresh the times of the detail pane
+		return m, tick()
+	case tea.KeyMsg:
+		l := m.current()
+		if msg.Type == tea.KeyCtrlC {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		if l.FilterState() == list.Filtering {
+			// enter accepts the filter and opens the entry it selects
+			if msg.Type == tea.KeyEnter {
+				*l, _ = l.Update(msg)
+				return m.open()
+			}
+			break
+		}
+		switch msg.Type {
+		case tea.KeyEnter, tea.KeyRight:
+			return m.open()
+		case tea.KeyEsc, tea.KeyLeft:
+			if l.FilterState() == list.FilterApplied {
+				l.ResetFilter()
+				return m, nil
+			}
+			return m.back()
+		case tea.KeyRunes:
+			// typing filters the current level, starting with the first letter
+			if !key.Matches(msg, l.KeyMap.Filter) {
+				var cmd tea.Cmd
+				*l, cmd = l.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(l.KeyMap.Filter.Keys()[0])})
+				cmds = append(cmds, cmd)
+			}
+		}
+	}
+	var cmd tea.Cmd
+	*m.current(), cmd = m.current().Update(msg)
+	return m, tea.Batch(append(cmds, cmd)...)
+}
+
+func (m explorer) View() string {
+	if m.quitting || m.selected.timezone != "" {
+		return ""
+	}
+	l := m.levels[len(m.levels)-1]
+	lines := exploreDetails(m.level(), m.path, l.SelectedItem(), m.now())
+	return "\n" + lipgloss.JoinHorizontal(lipgloss.Top, l.View(), detailStyle.Render(strings.Join(lines, "\n")))
+}
+
+// exploreDetails returns the lines of the detail pane for the entry 'listItem' at 'level',
+// reached through 'path', at the instant 'now'.
+func exploreDetails(level exploreLevel, path []string, listItem list.Item, now time.Time) []string {
+	switch selected := listItem.(type) {
+	case item:
+		name := string(selected)
+		switch level {
+		case regionLevel:
+			return []string{name, fmt.Sprintf("%d countries", len(regionCountries(name)))}
+		case countryLevel:
+			lines := []string{
+				name,
+				fmt.Sprintf("Codes:   %v", countryCodes(name)),
+				fmt.Sprintf("Zones:   %d", len(tzdata.CountryToIanaTimezone[name])),
+			}
+			return append(lines, zoneDetails(regionZone(name, path[0]), now)...)
+		case cityLevel:
+			lines := []string{fmt.Sprintf("%v, %v", name, path[1])}
+			return append(lines, zoneDetails(tzdata.CityToIanaTimezone[name]["tz"], now)...)
+		}
+	case zoneItem:
+		lines := []string{selected.city, fmt.Sprintf("Areas:   %v", selected.region)}
+		return append(lines, zoneDetails(selected.zone, now)...)
+	}
+	return []string{"Nothing selected"}
+}
+
+// zoneDetails returns the lines describing a timezone at the instant 'now': its local time,
+// UTC offset and abbreviation, whether it observes DST and its next offset change.
+func zoneDetails(tz string, now time.Time) []string {
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return []string{fmt.Sprintf("Zone:    %v (unknown)", tz)}
+	}
+	local := now.In(loc)
+	abbreviation, offset := local.Zone()
+	dst := "no"
+	if rules := zoneDSTRules(tz); rules != "none" && rules != "unknown" {
+		dst = "yes, not in effect"
+		if local.IsDST() {
+			dst = "yes, in effect"
+		}
+	}
+	next := "none"
+	if change, ok := nextOffsetChange(loc, now); ok {
+		_, nextOffset := change.Zone()
+		next = fmt.Sprintf("%v to %v, in %v", change.In(loc).Format(config.shortTimeLayout()), formatUTCOffset(nextOffset), formatCountdown(change.Sub(now)))
+	}
+	return []string{
+		"",
+		fmt.Sprintf("Zone:    %v", tz),
+		fmt.Sprintf("Time:    %v", local.Format(config.timeLayout())),
+		fmt.Sprintf("Offset:  %v (%v)", formatUTCOffset(offset), abbreviation),
+		fmt.Sprintf("DST:     %v", dst),
+		fmt.Sprintf("Next:    %v", next),
+	}
+}
+
+// runExplorer runs the explorer 'm' and returns the location chosen, which is empty if the
+// user quits.
+func runExplorer(m explorer) locationInfo {
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		fmt.Println("Error running program:", err)
+		os.Exit(1)
+	}
+	return final.(explorer).selected
+}
+
+// Explore browses the regions, countries, timezones and cities level by level and prints the
+// current time of the city or timezone chosen, like a lookup with 'opts'.
+func Explore(r *Resolver, opts LookupOptions) error {
+	if !r.interactive() {
+		return errors.New("Exploring needs a terminal and the table output.")
+	}
+	if opts.Home == "" {
+		opts.Home = r.home
+	}
+	home, err := r.homeLocation(opts.Home)
+	if err != nil {
+		return err
+	}
+	location := runExplorer(newExplorer(time.Now))
+	if location.timezone == "" {
+		return nil
+	}
+	if location.formattedTime, err = r.formatTime(location.timezone, time.Now()); err != nil {
+		return err
+	}
+	r.showLocation(location, home, opts.Daylight)
+	return nil
+}
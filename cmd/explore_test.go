@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// exploreNow is the instant the explorer tests are run at.
+func exploreNow() time.Time {
+	return time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+}
+
+func TestExploreRegions(t *testing.T) {
+	var regions []string
+	for _, region := range exploreRegions() {
+		regions = append(regions, string(region.(item)))
+	}
+	for _, want := range []string{"Africa", "America", "Asia", "Europe", "Pacific"} {
+		if !slices.Contains(regions, want) {
+			t.Fatalf("`exploreRegions()` = %v, want %v among them", regions, want)
+		}
+	}
+	if countries := regionCountries("Asia"); !slices.Contains(countries, "Nepal") || slices.Contains(countries, "France") {
+		t.Fatalf("`regionCountries(Asia)` = %v, want Nepal and not France", countries)
+	}
+}
+
+func TestRegionZone(t *testing.T) {
+	tests := []struct {
+		country string
+		region  string
+		want    string
+	}{
+		{"United States of America", "America", "America/New_York"},
+		{"United States of America", "Pacific", "Pacific/Honolulu"},
+		{"Nepal", "Asia", "Asia/Kathmandu"},
+		{"Russia", "", "Europe/Moscow"},
+	}
+	for _, test := range tests {
+		if got := regionZone(test.country, test.region); got != test.want {
+			t.Fatalf("`regionZone(%v, %v)` = %v, want %v", test.country, test.region, got, test.want)
+		}
+	}
+}
+
+func TestExplorerDrillDown(t *testing.T) {
+	var m tea.Model = newExplorer(exploreNow)
+	steps := []struct {
+		choose    string // the item to select before pressing enter, if any
+		wantLevel exploreLevel
+	}{
+		{"Asia", countryLevel},
+		{"Nepal", zoneLevel},
+		{"", cityLevel},
+	}
+	for _, step := range steps {
+		e := m.(explorer)
+		selectItem(e.current(), step.choose)
+		m, _ = e.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if got := m.(explorer).level(); got != step.wantLevel {
+			t.Fatalf("opening %q got to level %v, want %v", step.choose, got, step.wantLevel)
+		}
+	}
+	if path := m.(explorer).path; !slices.Equal(path, []string{"Asia", "Nepal", "Asia/Kathmandu"}) {
+		t.Fatalf("the explorer's path = %v, want Asia, Nepal and Asia/Kathmandu", path)
+	}
+
+	// going back and forth keeps the path in step with the levels
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if e := m.(explorer); e.level() != zoneLevel || !slices.Equal(e.path, []string{"Asia", "Nepal"}) {
+		t.Fatalf("going back got to level %v at %v, want the zones of Nepal", e.level(), e.path)
+	}
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyRight})
+	e := m.(explorer)
+	selectItem(e.current(), "Kathmandu")
+	m, _ = e.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	want := locationInfo{city: "Kathmandu", country: "Nepal", timezone: "Asia/Kathmandu"}
+	if got := m.(explorer).selected; got != want {
+		t.Fatalf("choosing Kathmandu selected %+v, want %+v", got, want)
+	}
+}
+
+func TestExplorerTypeToFilter(t *testing.T) {
+	m, _ := newExplorer(exploreNow).Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	e := m.(explorer)
+	l := e.current()
+	if l.FilterState() != list.Filtering || l.FilterValue() != "e" {
+		t.Fatalf("typing 'e' left the filter %v at %q, want filtering by \"e\"", l.FilterState(), l.FilterValue())
+	}
+	// while filtering, esc cancels the filter instead of going back
+	m, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if e = m.(explorer); e.quitting || e.current().FilterState() != list.Unfiltered {
+		t.Fatalf("esc while filtering quit or kept the filter %v", e.current().FilterState())
+	}
+}
+
+func TestExplorerPicksCountryZone(t *testing.T) {
+	m := newExplorer(exploreNow)
+	m.deepest = zoneLevel
+	m.openAt("Russia")
+	if zone, ok := m.current().SelectedItem().(zoneItem); !ok || zone.zone != "Europe/Moscow" {
+		t.Fatalf("the explorer at Russia selected %v, want Europe/Moscow", m.current().SelectedItem())
+	}
+	if view := m.View(); !strings.Contains(view, "World › Europe › Russia") || !strings.Contains(view, "Zone:    Europe/Moscow") {
+		t.Fatalf("the explorer at Russia shows %q, want its path and the details of Europe/Moscow", view)
+	}
+	final, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	want := locationInfo{country: "Russia", timezone: "Europe/Moscow"}
+	if got := final.(explorer).selected; got != want {
+		t.Fatalf("choosing in the explorer at Russia selected %+v, want %+v", got, want)
+	}
+}
+
+func TestExplorerPicksOtherCountry(t *testing.T) {
+	m := newExplorer(exploreNow)
+	m.deepest = zoneLevel
+	m.openAt("Russia")
+	// going back from the zones of Russia to the regions and into Germany
+	var final tea.Model = m
+	for range 2 {
+		final, _ = final.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	}
+	for _, choose := range []string{"Europe", "Germany"} {
+		e := final.(explorer)
+		selectItem(e.current(), choose)
+		final, _ = e.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+	e := final.(explorer)
+	selectZone(e.current(), "Europe/Berlin")
+	final, _ = e.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	want := locationInfo{country: "Germany", timezone: "Europe/Berlin"}
+	if got := final.(explorer).selected; got != want {
+		t.Fatalf("choosing Berlin after going back from Russia selected %+v, want %+v", got, want)
+	}
+}
+
+func TestZoneDetails(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	config.Clock = 24
+	tests := []struct {
+		tz   string
+		want []string
+	}{
+		{"America/New_York", []string{
+			"",
+			"Zone:    America/New_York",
+			"Time:    Wed, 01 Jul 2026 08:00:00",
+			"Offset:  UTC-04:00 (EDT)",
+			"DST:     yes, in effect",
+			"Next:    Sun, 01 Nov 2026 01:00 to UTC-05:00, in 122d 18h 00m 00s",
+		}},
+		{"Asia/Kathmandu", []string{
+			"",
+			"Zone:    Asia/Kathmandu",
+			"Time:    Wed, 01 Jul 2026 17:45:00",
+			"Offset:  UTC+05:45 (+0545)",
+			"DST:     no",
+			"Next:    none",
+		}},
+	}
+	for _, test := range tests {
+		if got := zoneDetails(test.tz, exploreNow()); !slices.Equal(got, test.want) {
+			t.Fatalf("`zoneDetails(%v)` = %q, want %q", test.tz, got, test.want)
+		}
+	}
+}
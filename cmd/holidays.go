@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// allCountries is the key of holidays observed in every country, e.g. company holidays.
+const allCountries = "all"
+
+// holiday is a holiday as written in JSON holiday files and tzdata.HolidaysJSON.
+type holiday struct {
+	Date   string `json:"date"`          // The first day like 2026-10-18
+	End    string `json:"end,omitempty"` // The last day of a holiday lasting several days
+	Name   string `json:"name"`
+	Yearly bool   `json:"yearly,omitempty"` // The holiday is on the same date every year
+}
+
+// holidayRange is a parsed holiday, from 'start' to 'end' inclusive, both at midnight UTC.
+type holidayRange struct {
+	name       string
+	start, end time.Time
+	yearly     bool
+}
+
+// contains reports whether the holiday includes 'date', a day at midnight UTC.
+func (h holidayRange) contains(date time.Time) bool {
+	if h.yearly {
+		// move the holiday to the year of 'date'
+		years := date.Year() - h.start.Year()
+		start, end := h.start.AddDate(years, 0, 0), h.end.AddDate(years, 0, 0)
+		// a holiday spanning New Year may have started the year before
+		if date.Before(start) {
+			start, end = start.AddDate(-1, 0, 0), end.AddDate(-1, 0, 0)
+		}
+		return !date.Before(start) && !date.After(end)
+	}
+	return !date.Before(h.start) && !date.After(h.end)
+}
+
+// parseHolidays validates holidays read from JSON.
+func parseHolidays(holidays []holiday) ([]holidayRange, error) {
+	ranges := make([]holidayRange, 0, len(holidays))
+	for _, h := range holidays {
+		start, err := time.Parse("2006-01-02", h.Date)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid holiday date '%s', use e.g. 2026-10-18.", h.Date)
+		}
+		end := start
+		if h.End != "" {
+			if end, err = time.Parse("2006-01-02", h.End); err != nil || end.Before(start) {
+				return nil, fmt.Errorf("Invalid end '%s' of the holiday on %s.", h.End, h.Date)
+			}
+		}
+		if h.Name == "" {
+			return nil, fmt.Errorf("The holiday on %s has no name.", h.Date)
+		}
+		ranges = append(ranges, holidayRange{name: h.Name, start: start, end: end, yearly: h.Yearly})
+	}
+	return ranges, nil
+}
+
+// builtinHolidays returns the embedded holidays keyed by alpha-2 code, parsed once.
+var builtinHolidays = sync.OnceValue(func() map[string][]holidayRange {
+	var dataset map[string][]holiday
+	if err := json.Unmarshal(tzdata.HolidaysJSON, &dataset); err != nil {
+		panic(fmt.Sprintf("tzdata.HolidaysJSON: %v", err))
+	}
+	holidays := make(map[string][]holidayRange, len(dataset))
+	for code, list := range dataset {
+		ranges, err := parseHolidays(list)
+		if err != nil {
+			panic(fmt.Sprintf("tzdata.HolidaysJSON: %s: %v", code, err))
+		}
+		holidays[code] = ranges
+	}
+	return holidays
+})
+
+// readHolidayFile reads the holidays of an iCalendar (.ics) or JSON file. A JSON file
+// holds a list of holidays like tzdata.HolidaysJSON has for each country.
+func readHolidayFile(path string) ([]holidayRange, error) {
+	content, err := os.ReadFile(expandHome(path))
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".ics", ".ical":
+		ranges, err := parseICSHolidays(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		return ranges, nil
+	case ".json":
+		var list []holiday
+		if err := json.Unmarshal(content, &list); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		ranges, err := parseHolidays(list)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+		return ranges, nil
+	}
+	return nil, fmt.Errorf("%s: holiday files must be .ics or .json", path)
+}
+
+// expandHome replaces a leading '~/' of a path with the user's home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// parseICSHolidays returns the events of an iCalendar file as holidays. Events with
+// 'RRULE:FREQ=YEARLY' repeat every year; other rules are not supported.
+func parseICSHolidays(content string) ([]holidayRange, error) {
+	// unfold the continuation lines
+	content = strings.NewReplacer("\r\n ", "", "\r\n\t", "", "\n ", "", "\n\t", "").Replace(content)
+	var ranges []holidayRange
+	var event *holidayRange
+	hasEnd := false
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		property, _, _ := strings.Cut(name, ";")
+		switch strings.ToUpper(property) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				event, hasEnd = &holidayRange{}, false
+			}
+		case "END":
+			if event == nil || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			if event.start.IsZero() {
+				return nil, fmt.Errorf("event '%s' has no DTSTART", event.name)
+			}
+			if !hasEnd {
+				event.end = event.start
+			}
+			ranges = append(ranges, *event)
+			event = nil
+		case "DTSTART", "DTEND":
+			if event == nil {
+				continue
+			}
+			date, err := time.Parse("20060102", value[:min(len(value), 8)])
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s '%s'", property, value)
+			}
+			if strings.EqualFold(property, "DTSTART") {
+				event.start = date
+			} else {
+				// the end of an all-day event is the day after it
+				if !strings.Contains(value, "T") {
+					date = date.AddDate(0, 0, -1)
+				}
+				event.end, hasEnd = date, true
+			}
+		case "SUMMARY":
+			if event != nil {
+				event.name = icsUnescape(value)
+			}
+		case "RRULE":
+			if event != nil {
+				event.yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+			}
+		}
+	}
+	for i := range ranges {
+		if ranges[i].end.Before(ranges[i].start) {
+			ranges[i].end = ranges[i].start
+		}
+	}
+	return ranges, nil
+}
+
+// icsUnescape reverses icsEscape.
+func icsUnescape(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
+
+// countryCode returns the alpha-2 code of a country name, if any.
+func countryCode(country string) (string, bool) {
+	for code, name := range tzdata.Alpha2ToCountry {
+		if canonicalCountry(name) == country {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// countryHolidays returns the holidays of a country by alpha-2 code: the embedded ones,
+// those of the files configured for the country and those configured for every country,
+// as read when the configuration was loaded.
+func (r *Resolver) countryHolidays(code string) []holidayRange {
+	holidays := append([]holidayRange{}, builtinHolidays()[code]...)
+	for _, key := range []string{code, allCountries} {
+		holidays = append(holidays, r.holidays[key]...)
+	}
+	return holidays
+}
+
+// holidayOn returns the names of the holidays of a location on the day of 't' in the
+// location, joined by ", ", or "" if it isn't a holiday there.
+func (r *Resolver) holidayOn(location locationInfo, t time.Time) string {
+	country := location.country
+	if country == "" {
+		country = zoneCountry(location.timezone)
+	}
+	code, _ := countryCode(country)
+	holidays := r.countryHolidays(code)
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	var names []string
+	for _, h := range holidays {
+		if h.contains(date) {
+			names = append(names, h.name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// holidayAt returns the holidays of a location on its day at the instant 't', like holidayOn.
+func (r *Resolver) holidayAt(location locationInfo, t time.Time) (string, error) {
+	loc, err := loadLocation(location.timezone)
+	if err != nil {
+		return "", err
+	}
+	return r.holidayOn(location, t.In(loc)), nil
+}
+
+// ShowHolidays prints the holidays of a country (a name or an alpha-2/alpha-3 code) in a year.
+func ShowHolidays(r *Resolver, country string, year int) {
+	countries, err := r.getMatchingLocation("", country)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	name := countries[0]
+	if code, ok := findSubdivision(name); ok {
+		name = subdivisionLocation(code).country
+	}
+	code, ok := countryCode(name)
+	if !ok {
+		fmt.Printf("\nError: %v\n", fmt.Errorf("Country '%s' has no alpha-2 code.", name))
+		return
+	}
+	holidays := r.countryHolidays(code)
+
+	var rows []table.Row
+	for _, h := range holidaysIn(holidays, year) {
+		days := int(h.end.Sub(h.start).Hours()/24) + 1
+		rows = append(rows, table.Row{h.start.Format("2006-01-02"), h.start.Format("Mon"), fmt.Sprint(days), h.name})
+	}
+	if len(rows) == 0 {
+		fmt.Printf("No holidays known for %v in %d.\n", name, year)
+		if year > tzdata.HolidaysYear {
+			fmt.Printf("The embedded holidays cover the years up to %d; add yours with `ktz config set holidays.%v <file>`.\n", tzdata.HolidaysYear, code)
+		}
+		return
+	}
+	columns := []table.Column{
+		{Title: "Date", Width: 12},
+		{Title: "Weekday", Width: 8},
+		{Title: "Days", Width: 5},
+		{Title: "Holiday", Width: 40},
+	}
+	m := initialModel(tableView)
+	m.table.SetHeight(len(rows))
+	runTable(fmt.Sprintf("Holidays in %v (%v), %d:", name, code, year), m, columns, rows)
+}
+
+// holidaysIn returns the holidays starting in 'year', with yearly ones moved to it, sorted by date.
+func holidaysIn(holidays []holidayRange, year int) []holidayRange {
+	var inYear []holidayRange
+	for _, h := range holidays {
+		if h.yearly {
+			years := year - h.start.Year()
+			h.start, h.end = h.start.AddDate(years, 0, 0), h.end.AddDate(years, 0, 0)
+		}
+		if h.start.Year() == year {
+			inYear = append(inYear, h)
+		}
+	}
+	sort.SliceStable(inYear, func(i, j int) bool { return inYear[i].start.Before(inYear[j].start) })
+	return inYear
+}
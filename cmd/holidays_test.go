@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+func TestBuiltinHolidays(t *testing.T) {
+	holidays := builtinHolidays()
+	if len(holidays) == 0 {
+		t.Fatal("`builtinHolidays()` is empty")
+	}
+	for code := range holidays {
+		if _, ok := tzdata.Alpha2ToCountry[code]; !ok {
+			t.Fatalf("`tzdata.HolidaysJSON` has unknown alpha-2 code %q", code)
+		}
+	}
+	kathmandu := locationInfo{city: "Kathmandu", country: "Nepal", timezone: "Asia/Kathmandu"}
+	if got := DefaultResolver().holidayOn(kathmandu, time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)); got != "Dashain" {
+		t.Fatalf("`holidayOn(Kathmandu, 2026-10-20)` = %q, want Dashain", got)
+	}
+	if got := DefaultResolver().holidayOn(kathmandu, time.Date(2026, 10, 24, 12, 0, 0, 0, time.UTC)); got != "" {
+		t.Fatalf("`holidayOn(Kathmandu, 2026-10-24)` = %q, want none", got)
+	}
+	// a zone without a country is matched to the country using it
+	if got := DefaultResolver().holidayOn(locationInfo{timezone: "Europe/Berlin"}, time.Date(2030, 10, 3, 0, 0, 0, 0, time.UTC)); got != "Tag der Deutschen Einheit" {
+		t.Fatalf("`holidayOn(Europe/Berlin, 2030-10-03)` = %q", got)
+	}
+}
+
+func TestParseICSHolidays(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20261224\r\nDTEND;VALUE=DATE:20261227\r\nSUMMARY:Company\r\n  shutdown\\, all offices\r\nEND:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\nDTSTART;VALUE=DATE:20260612\r\nRRULE:FREQ=YEARLY\r\nSUMMARY:Founders' Day\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	holidays, err := parseICSHolidays(ics)
+	if err != nil {
+		t.Fatalf("`parseICSHolidays()` error: %v", err)
+	}
+	if len(holidays) != 2 {
+		t.Fatalf("`parseICSHolidays()` returned %d holidays, want 2", len(holidays))
+	}
+	shutdown := holidays[0]
+	if shutdown.name != "Company shutdown, all offices" || shutdown.start.Day() != 24 || shutdown.end.Day() != 26 || shutdown.yearly {
+		t.Fatalf("`parseICSHolidays()` first holiday = %+v", shutdown)
+	}
+	if founders := holidays[1]; !founders.yearly || !founders.contains(time.Date(2031, 6, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("`parseICSHolidays()` second holiday = %+v", founders)
+	}
+	if _, err := parseICSHolidays("BEGIN:VEVENT\nSUMMARY:No date\nEND:VEVENT\n"); err == nil {
+		t.Fatal("`parseICSHolidays()` accepted an event without DTSTART")
+	}
+}
+
+func TestHolidayRangeContains(t *testing.T) {
+	newYear := holidayRange{
+		name:   "New Year break",
+		start:  time.Date(2026, 12, 30, 0, 0, 0, 0, time.UTC),
+		end:    time.Date(2027, 1, 2, 0, 0, 0, 0, time.UTC),
+		yearly: true,
+	}
+	tests := []struct {
+		date time.Time
+		want bool
+	}{
+		{time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2029, 1, 2, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2029, 1, 3, 0, 0, 0, 0, time.UTC), false},
+		{time.Date(2020, 12, 29, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		if got := newYear.contains(tt.date); got != tt.want {
+			t.Fatalf("`contains(%v)` = %v, want %v", tt.date, got, tt.want)
+		}
+	}
+}
+
+func TestConfiguredHolidays(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "company.json")
+	if err := os.WriteFile(path, []byte(`[{"date": "2026-07-10", "end": "2026-07-11", "name": "Offsite"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := configSections["holidays"](&config, allCountries, path); err != nil {
+		t.Fatalf("setting holidays.all: %v", err)
+	}
+	if err := configSections["holidays"](&config, "XX", path); err == nil {
+		t.Fatal("`holidays.XX` was accepted")
+	}
+	broken := filepath.Join(dir, "broken.json")
+	os.WriteFile(broken, []byte(`[{"date": "10/07/2026", "name": "Offsite"}]`), 0o644)
+	if err := configSections["holidays"](&config, "NP", broken); err == nil {
+		t.Fatal("a holiday file with an invalid date was accepted")
+	}
+
+	// the file is read when the configuration is loaded, not on every lookup
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	tokyo := locationInfo{city: "Tokyo", country: "Japan", timezone: "Asia/Tokyo"}
+	if got := DefaultResolver().holidayOn(tokyo, time.Date(2026, 7, 11, 9, 0, 0, 0, time.UTC)); got != "Offsite" {
+		t.Fatalf("`holidayOn(Tokyo, 2026-07-11)` = %q, want Offsite", got)
+	}
+	if status, _ := DefaultResolver().locationStatus(tokyo, time.Date(2026, 7, 10, 3, 0, 0, 0, time.UTC)); status != statusHoliday {
+		t.Fatalf("`locationStatus(Tokyo)` on the offsite = %q, want %q", status, statusHoliday)
+	}
+	jp := DefaultResolver().countryHolidays("JP")
+	var names []string
+	for _, h := range holidaysIn(jp, 2026) {
+		if h.start.Month() == time.July {
+			names = append(names, h.name)
+		}
+	}
+	if len(names) != 2 || names[0] != "Offsite" {
+		t.Fatalf("`holidaysIn(JP, 2026)` in July = %q, want the offsite before Marine Day", names)
+	}
+}
+
+func TestConfiguredHolidaysRelativePath(t *testing.T) {
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	dir, elsewhere := t.TempDir(), t.TempDir()
+	holidays := `[{"date": "2026-07-10", "name": "Offsite"}]`
+	os.WriteFile(filepath.Join(dir, "company.json"), []byte(holidays), 0o644)
+	path := filepath.Join(dir, "config.toml")
+	os.WriteFile(path, []byte("[holidays]\nJP = \"company.json\"\n"), 0o644)
+	t.Setenv("KTZ_CONFIG", path)
+
+	// a relative file in the configuration is relative to the configuration's directory
+	os.Chdir(elsewhere)
+	if err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig from another directory returned error %v", err)
+	}
+	tokyo := locationInfo{city: "Tokyo", country: "Japan", timezone: "Asia/Tokyo"}
+	if got := DefaultResolver().holidayOn(tokyo, time.Date(2026, 7, 10, 9, 0, 0, 0, time.UTC)); got != "Offsite" {
+		t.Fatalf("`holidayOn(Tokyo, 2026-07-10)` = %q, want Offsite", got)
+	}
+
+	// `config set` stores the file of the current directory by its full path
+	os.WriteFile(filepath.Join(elsewhere, "office.json"), []byte(holidays), 0o644)
+	if err := ConfigSet("holidays.NP", []string{"office.json"}); err != nil {
+		t.Fatalf("ConfigSet(holidays.NP, office.json) returned error %v", err)
+	}
+	os.Chdir(dir)
+	if err := LoadConfig(); err != nil {
+		t.Fatalf("LoadConfig after ConfigSet from another directory returned error %v", err)
+	}
+	if got := config.Holidays["NP"]; len(got) != 1 || !filepath.IsAbs(got[0]) {
+		t.Fatalf("holidays.NP = %q, want the full path of office.json", got)
+	}
+}
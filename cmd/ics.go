@@ -0,0 +1,327 @@
+package cmd
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kritibb/ktz/tzif"
+)
+
+// icsWriter collects iCalendar content lines, folded at 75 octets and ended with CRLF as RFC 5545 requires.
+type icsWriter struct {
+	b strings.Builder
+}
+
+// line writes a content line like "TZID:Asia/Kathmandu".
+func (w *icsWriter) line(format string, args ...any) {
+	line := fmt.Sprintf(format, args...)
+	for len(line) > 75 {
+		// don't split a UTF-8 sequence
+		cut := 75
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.b.WriteString(line[:cut] + "\r\n")
+		line = " " + line[cut:]
+	}
+	w.b.WriteString(line + "\r\n")
+}
+
+// writeTo writes the collected lines.
+func (w *icsWriter) writeTo(out io.Writer) {
+	io.WriteString(out, w.b.String())
+}
+
+// icsEscape escapes a TEXT value.
+func icsEscape(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(text)
+}
+
+// icsParam quotes a parameter value if it contains a ',', ':' or ';', e.g. a TZID that is a POSIX TZ string.
+func icsParam(value string) string {
+	if strings.ContainsAny(value, ",:;") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// formatICSLocal formats a local date-time like "20261103T093000".
+func formatICSLocal(t time.Time) string {
+	return t.Format("20060102T150405")
+}
+
+// formatICSOffset formats a UTC offset in seconds like "+0545" or "-034652".
+func formatICSOffset(offset int32) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	formatted := fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+	if offset%60 != 0 {
+		formatted += fmt.Sprintf("%02d", offset%60)
+	}
+	return formatted
+}
+
+// offsetChange is a transition of a TZif file that changes the UTC offset, the DST flag or the abbreviation.
+type offsetChange struct {
+	time     int64
+	from, to tzif.LocalTimeType
+}
+
+// offsetChanges returns the transitions of a TZif file that change something.
+func offsetChanges(file *tzif.File) []offsetChange {
+	var changes []offsetChange
+	previous := file.Types[0]
+	for _, transition := range file.Transitions {
+		t := file.Types[transition.Type]
+		if t.Offset != previous.Offset || t.IsDST != previous.IsDST || t.Abbreviation != previous.Abbreviation {
+			changes = append(changes, offsetChange{transition.Time, previous, t})
+		}
+		previous = t
+	}
+	return changes
+}
+
+// observance is a STANDARD or DAYLIGHT component of a VTIMEZONE: a change happening
+// once at 'onset', or also yearly after it by 'rrule' or on the dates of 'rdates'.
+type observance struct {
+	onset    int64
+	from, to tzif.LocalTimeType
+	rrule    string
+	rdates   []int64
+}
+
+// write writes the observance; its times are in the local time before the change.
+func (o observance) write(w *icsWriter) {
+	kind := "STANDARD"
+	if o.to.IsDST {
+		kind = "DAYLIGHT"
+	}
+	local := func(unix int64) string {
+		return formatICSLocal(time.Unix(unix+int64(o.from.Offset), 0).UTC())
+	}
+	w.line("BEGIN:%s", kind)
+	w.line("DTSTART:%s", local(o.onset))
+	w.line("TZOFFSETFROM:%s", formatICSOffset(o.from.Offset))
+	w.line("TZOFFSETTO:%s", formatICSOffset(o.to.Offset))
+	if o.rrule != "" {
+		w.line("RRULE:%s", o.rrule)
+	}
+	if len(o.rdates) > 0 {
+		dates := make([]string, len(o.rdates))
+		for i, rdate := range o.rdates {
+			dates[i] = local(rdate)
+		}
+		w.line("RDATE:%s", strings.Join(dates, ","))
+	}
+	if o.to.Abbreviation != "" {
+		w.line("TZNAME:%s", icsEscape(o.to.Abbreviation))
+	}
+	w.line("END:%s", kind)
+}
+
+// rdateYears is the number of years listed for DST rules that can't be written as an RRULE.
+const rdateYears = 10
+
+// ruleRRULE returns the RRULE of a POSIX TZ rule like "FREQ=YEARLY;BYMONTH=3;BYDAY=2SU",
+// or false if the rule can't be written as one, e.g. for "J60" or "M3.4.4/26".
+func ruleRRULE(rule tzif.Rule) (string, bool) {
+	if rule.Kind != tzif.MonthWeekDay || rule.Time < 0 || rule.Time >= 24*3600 {
+		return "", false
+	}
+	week := rule.Week
+	if week == 5 {
+		week = -1
+	}
+	weekdays := []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+	return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", rule.Month, week, weekdays[rule.Day]), true
+}
+
+// vtimezoneObservances returns the observances describing a TZif file from 'from' on: the
+// explicit transitions still needed at 'from', then the yearly rules of its POSIX TZ footer.
+// Explicit transitions that the footer predicts are left to the rules.
+func vtimezoneObservances(file *tzif.File, from time.Time) []observance {
+	changes := offsetChanges(file)
+	tz, err := tzif.ParsePOSIX(file.Footer)
+	hasRules := err == nil && tz.HasDST()
+	stdType := tzif.LocalTimeType{Offset: tz.StdOffset, Abbreviation: tz.StdName}
+	dstType := tzif.LocalTimeType{Offset: tz.DSTOffset, IsDST: true, Abbreviation: tz.DSTName}
+
+	same := func(a, b tzif.LocalTimeType) bool {
+		return a.Offset == b.Offset && a.IsDST == b.IsDST && a.Abbreviation == b.Abbreviation
+	}
+	predicted := func(change offsetChange) bool {
+		year := time.Unix(change.time, 0).UTC().Year()
+		for y := year - 1; y <= year+1; y++ {
+			start, end, _ := tz.Transitions(y)
+			if change.time == start && same(change.to, dstType) || change.time == end && same(change.to, stdType) {
+				return true
+			}
+		}
+		return false
+	}
+	ruleStart := len(changes)
+	for hasRules && ruleStart > 0 && predicted(changes[ruleStart-1]) {
+		ruleStart--
+	}
+
+	// the change in effect at 'from' is the first one needed
+	first := 0
+	for i, change := range changes[:ruleStart] {
+		if change.time <= from.Unix() {
+			first = i
+		}
+	}
+	var observances []observance
+	for _, change := range changes[first:ruleStart] {
+		observances = append(observances, observance{onset: change.time, from: change.from, to: change.to})
+	}
+
+	if !hasRules {
+		if len(observances) == 0 {
+			// a zone without changes, like Etc/UTC
+			current := file.Types[0]
+			if len(changes) > 0 {
+				current = changes[len(changes)-1].to
+			}
+			if err == nil {
+				current = stdType
+			}
+			epoch := -int64(current.Offset)
+			observances = append(observances, observance{onset: epoch, from: current, to: current})
+		}
+		return observances
+	}
+
+	// the rules start with the first change they predict, or after the last explicit one,
+	// but not before the year before 'from' so that a southern summer is covered
+	notBefore := int64(math.MinInt64)
+	if ruleStart < len(changes) {
+		notBefore = changes[ruleStart].time
+	} else if len(changes) > 0 {
+		notBefore = changes[len(changes)-1].time + 1
+	}
+	var rules []observance
+	for _, rule := range []struct {
+		rule     tzif.Rule
+		from, to tzif.LocalTimeType
+		isStart  bool
+	}{
+		{tz.Start, stdType, dstType, true},
+		{tz.End, dstType, stdType, false},
+	} {
+		onset := func(year int) int64 {
+			start, end, _ := tz.Transitions(year)
+			if rule.isStart {
+				return start
+			}
+			return end
+		}
+		year := from.UTC().Year() - 1
+		for onset(year) < notBefore {
+			year++
+		}
+		o := observance{onset: onset(year), from: rule.from, to: rule.to}
+		if rrule, ok := ruleRRULE(rule.rule); ok {
+			o.rrule = rrule
+		} else {
+			for y := year + 1; y < year+rdateYears; y++ {
+				o.rdates = append(o.rdates, onset(y))
+			}
+		}
+		rules = append(rules, o)
+	}
+	// explicit transitions are only needed if the rules start after 'from'
+	if min(rules[0].onset, rules[1].onset) <= from.Unix() {
+		observances = nil
+	}
+	return append(observances, rules...)
+}
+
+// writeVTimezone writes a VTIMEZONE component for the timezone 'tzid', valid from 'from' on.
+func writeVTimezone(w *icsWriter, tzid string, from time.Time) error {
+	file, err := zoneFile(tzid)
+	if err != nil {
+		return err
+	}
+	w.line("BEGIN:VTIMEZONE")
+	w.line("TZID:%s", icsEscape(tzid))
+	for _, o := range vtimezoneObservances(file, from) {
+		o.write(w)
+	}
+	w.line("END:VTIMEZONE")
+	return nil
+}
+
+// icsEvent is an event exported by `convert -ics`.
+type icsEvent struct {
+	summary     string
+	description string
+	start       time.Time // The start in the location the event is given in
+	duration    time.Duration
+}
+
+// hasTZID reports whether times in 'loc' can refer to a VTIMEZONE; times in UTC
+// or the unnamed system zone are written in UTC instead.
+func hasTZID(loc *time.Location) bool {
+	return loc != time.UTC && loc != time.Local && loc.String() != "UTC" && loc.String() != "Local"
+}
+
+// writeICSEvent writes a VCALENDAR with the event and, unless it's in UTC, the VTIMEZONE of its zone.
+// The 'now' parameter is the time stamp of the event.
+func writeICSEvent(out io.Writer, event icsEvent, now time.Time) error {
+	var w icsWriter
+	w.line("BEGIN:VCALENDAR")
+	w.line("VERSION:2.0")
+	w.line("PRODID:-//kritibb//ktz//EN")
+	w.line("CALSCALE:GREGORIAN")
+	loc := event.start.Location()
+	end := event.start.Add(event.duration)
+	dtstart := "DTSTART:" + event.start.UTC().Format("20060102T150405Z")
+	dtend := "DTEND:" + end.UTC().Format("20060102T150405Z")
+	if hasTZID(loc) {
+		if err := writeVTimezone(&w, loc.String(), event.start); err != nil {
+			return err
+		}
+		dtstart = fmt.Sprintf("DTSTART;TZID=%s:%s", icsParam(loc.String()), formatICSLocal(event.start))
+		dtend = fmt.Sprintf("DTEND;TZID=%s:%s", icsParam(loc.String()), formatICSLocal(end.In(loc)))
+	}
+	uid := fnv.New32a()
+	io.WriteString(uid, event.summary+loc.String())
+	w.line("BEGIN:VEVENT")
+	w.line("UID:%s-%08x@ktz", event.start.UTC().Format("20060102T150405Z"), uid.Sum32())
+	w.line("DTSTAMP:%s", now.UTC().Format("20060102T150405Z"))
+	w.line("%s", dtstart)
+	w.line("%s", dtend)
+	w.line("SUMMARY:%s", icsEscape(event.summary))
+	if event.description != "" {
+		w.line("DESCRIPTION:%s", icsEscape(event.description))
+	}
+	w.line("END:VEVENT")
+	w.line("END:VCALENDAR")
+	w.writeTo(out)
+	return nil
+}
+
+// ShowVTimezone prints the VTIMEZONE component of a place's timezone for the instants
+// from January 1 of 'fromYear' on.
+func ShowVTimezone(r *Resolver, place string, fromYear int) {
+	location, err := r.resolvePlace(place)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	var w icsWriter
+	if err := writeVTimezone(&w, location.timezone, time.Date(fromYear, 1, 1, 0, 0, 0, 0, time.UTC)); err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	w.writeTo(os.Stdout)
+}
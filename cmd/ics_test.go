@@ -0,0 +1,141 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// vtimezoneLines returns the content lines of the VTIMEZONE of a zone, unfolded.
+func vtimezoneLines(t *testing.T, zone string, from time.Time) []string {
+	t.Helper()
+	var w icsWriter
+	if err := writeVTimezone(&w, zone, from); err != nil {
+		t.Fatalf("`writeVTimezone(%v)` returned error %v", zone, err)
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(w.b.String(), "\r\n ", ""), "\r\n"), "\r\n")
+}
+
+func TestWriteVTimezone(t *testing.T) {
+	tests := []struct {
+		zone string
+		from time.Time
+		want []string
+	}{
+		{"America/New_York", time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC), []string{
+			"BEGIN:VTIMEZONE", "TZID:America/New_York",
+			"BEGIN:DAYLIGHT", "DTSTART:20250309T020000", "TZOFFSETFROM:-0500", "TZOFFSETTO:-0400",
+			"RRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=2SU", "TZNAME:EDT", "END:DAYLIGHT",
+			"BEGIN:STANDARD", "DTSTART:20251102T020000", "TZOFFSETFROM:-0400", "TZOFFSETTO:-0500",
+			"RRULE:FREQ=YEARLY;BYMONTH=11;BYDAY=1SU", "TZNAME:EST", "END:STANDARD",
+			"END:VTIMEZONE",
+		}},
+		{"Asia/Kathmandu", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []string{
+			"BEGIN:VTIMEZONE", "TZID:Asia/Kathmandu",
+			"BEGIN:STANDARD", "DTSTART:19860101T000000", "TZOFFSETFROM:+0530", "TZOFFSETTO:+0545",
+			"TZNAME:+0545", "END:STANDARD",
+			"END:VTIMEZONE",
+		}},
+		{"Etc/UTC", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), []string{
+			"BEGIN:VTIMEZONE", "TZID:Etc/UTC",
+			"BEGIN:STANDARD", "DTSTART:19700101T000000", "TZOFFSETFROM:+0000", "TZOFFSETTO:+0000",
+			"TZNAME:UTC", "END:STANDARD",
+			"END:VTIMEZONE",
+		}},
+	}
+	for _, test := range tests {
+		if got := vtimezoneLines(t, test.zone, test.from); !EqualSlices(got, test.want) {
+			t.Fatalf("`writeVTimezone(%v)` =\n%v\nwant\n%v", test.zone, strings.Join(got, "\n"), strings.Join(test.want, "\n"))
+		}
+	}
+}
+
+func TestVTimezoneObservances(t *testing.T) {
+	// before the 2007 rules, New York needs its explicit transitions
+	lines := vtimezoneLines(t, "America/New_York", time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC))
+	var starts []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "DTSTART:") {
+			starts = append(starts, strings.TrimPrefix(line, "DTSTART:"))
+		}
+	}
+	if len(starts) != 17 || starts[0] != "19991031T020000" || starts[15] != "20070311T020000" || starts[16] != "20071104T020000" {
+		t.Fatalf("`writeVTimezone(America/New_York)` onsets = %v", starts)
+	}
+
+	// the start rule "M3.4.4/26" has no RRULE, so its dates are listed
+	found := false
+	for _, line := range vtimezoneLines(t, "Asia/Jerusalem", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		if strings.HasPrefix(line, "RDATE:") {
+			found = strings.HasPrefix(line, "RDATE:20260327T020000,20270326T020000,")
+		}
+	}
+	if !found {
+		t.Fatalf("`writeVTimezone(Asia/Jerusalem)` lacks the RDATE of the DST start rule")
+	}
+}
+
+func TestICSWriterFolding(t *testing.T) {
+	var w icsWriter
+	w.line("DESCRIPTION:%s", strings.Repeat("Kathmandu ", 20))
+	for _, line := range strings.Split(strings.TrimSuffix(w.b.String(), "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line of %d octets: %q", len(line), line)
+		}
+	}
+	if got := strings.ReplaceAll(w.b.String(), "\r\n ", ""); got != "DESCRIPTION:"+strings.Repeat("Kathmandu ", 20)+"\r\n" {
+		t.Fatalf("unfolded line = %q", got)
+	}
+}
+
+func TestWriteICSEvent(t *testing.T) {
+	newYork, _ := loadLocation("America/New_York")
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	event := icsEvent{
+		summary:     "Standup, daily",
+		description: "Kathmandu: Tue, 03 Nov 2026 08:15 PM",
+		start:       time.Date(2026, 11, 3, 9, 30, 0, 0, newYork),
+		duration:    30 * time.Minute,
+	}
+	var b bytes.Buffer
+	if err := writeICSEvent(&b, event, now); err != nil {
+		t.Fatalf("`writeICSEvent()` returned error %v", err)
+	}
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n", "BEGIN:VTIMEZONE\r\nTZID:America/New_York\r\n",
+		"DTSTAMP:20261018T120000Z\r\n",
+		"DTSTART;TZID=America/New_York:20261103T093000\r\n",
+		"DTEND;TZID=America/New_York:20261103T100000\r\n",
+		"SUMMARY:Standup\\, daily\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("`writeICSEvent()` output lacks %q:\n%v", want, b.String())
+		}
+	}
+
+	// events in UTC have no VTIMEZONE
+	event.start = time.Date(2026, 11, 3, 9, 30, 0, 0, time.UTC)
+	b.Reset()
+	writeICSEvent(&b, event, now)
+	if strings.Contains(b.String(), "VTIMEZONE") || !strings.Contains(b.String(), "DTSTART:20261103T093000Z\r\n") {
+		t.Fatalf("`writeICSEvent()` in UTC =\n%v", b.String())
+	}
+}
+
+func TestConvertLocations(t *testing.T) {
+	kathmandu, _ := loadLocation("Asia/Kathmandu")
+	locations, err := DefaultResolver().convertLocations(time.Date(2026, 11, 3, 9, 30, 0, 0, kathmandu), []string{"London", "New York"})
+	if err != nil {
+		t.Fatalf("`convertLocations()` returned error %v", err)
+	}
+	// the day moved to its own column
+	if len(locations) != 2 || locations[0].difference != "-5h45m" || locations[0].day != "today, Tue" ||
+		locations[1].difference != "-10h45m" || locations[1].day != "» yesterday, Mon" {
+		t.Fatalf("`convertLocations()` = %+v", locations)
+	}
+	if _, err := DefaultResolver().convertLocations(time.Now(), []string{"Xyzzy"}); err == nil {
+		t.Fatalf("`convertLocations(Xyzzy)` returned no error")
+	}
+}
@@ -0,0 +1,254 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/kritibb/ktz/tzif"
+)
+
+// zoneMatch is a known timezone together with how closely it matches an inspected TZif file,
+// from 0 (nothing in common) to 1 (identical data).
+type zoneMatch struct {
+	Zone  string  `json:"zone"`
+	Score float64 `json:"score"`
+}
+
+// InspectTZif prints the content of the TZif file at 'path' and the zones it most likely corresponds to.
+func InspectTZif(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	file, err := tzif.Parse(data)
+	if err != nil {
+		fmt.Printf("\nError: %v: %v\n", path, err)
+		return
+	}
+	matches := matchZones(data, file, 3)
+	if config.Output == "json" {
+		writeInspectionJSON(os.Stdout, path, file, matches)
+		return
+	}
+	writeInspection(os.Stdout, path, file, matches)
+}
+
+// matchZones returns up to 'limit' known timezones that best match the TZif file, best first.
+func matchZones(data []byte, file *tzif.File, limit int) []zoneMatch {
+	var matches []zoneMatch
+	for _, zone := range knownZones() {
+		known, _, err := readZoneData(zone)
+		if err != nil {
+			continue
+		}
+		score := 1.0
+		if !bytes.Equal(data, known) {
+			knownFile, err := tzif.Parse(known)
+			if err != nil {
+				continue
+			}
+			score = similarity(file, knownFile)
+		}
+		if score > 0 {
+			matches = append(matches, zoneMatch{Zone: zone, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].Score > matches[j].Score })
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// similarity scores how alike two TZif files are: mostly by the transitions they share,
+// with the offset, abbreviation and DST flag they switch to, and partly by their footer.
+// Files that differ only in their encoding (e.g. "slim" vs "fat") score 1.
+func similarity(a, b *tzif.File) float64 {
+	type change struct {
+		time         int64
+		offset       int32
+		isDST        bool
+		abbreviation string
+	}
+	// transitions that change nothing, like those "fat" files add in 2037, are ignored
+	changes := func(f *tzif.File) map[change]bool {
+		set := map[change]bool{}
+		previous := f.Types[0]
+		for _, transition := range f.Transitions {
+			t := f.Types[transition.Type]
+			if t.Offset != previous.Offset || t.IsDST != previous.IsDST || t.Abbreviation != previous.Abbreviation {
+				set[change{transition.Time, t.Offset, t.IsDST, t.Abbreviation}] = true
+			}
+			previous = t
+		}
+		return set
+	}
+	changesA, changesB := changes(a), changes(b)
+	shared := 0
+	for c := range changesA {
+		if changesB[c] {
+			shared++
+		}
+	}
+	union := len(changesA) + len(changesB) - shared
+
+	transitionScore := 0.0
+	if union == 0 {
+		// zones without transitions, like Etc/GMT-5, match on their only type
+		if a.Types[0].Offset == b.Types[0].Offset && a.Types[0].Abbreviation == b.Types[0].Abbreviation {
+			transitionScore = 1
+		}
+	} else {
+		transitionScore = float64(shared) / float64(union)
+	}
+	footerScore := 0.0
+	if a.Footer == b.Footer {
+		footerScore = 1
+	}
+	if a.Version == 1 || b.Version == 1 {
+		// version 1 files have no footer to compare
+		return transitionScore
+	}
+	return 0.8*transitionScore + 0.2*footerScore
+}
+
+// formatTransitionTime formats a TZif time in UTC, or as raw seconds when it lies outside
+// the years 0 to 9999, like the "big bang" time zic writes before the first transition.
+func formatTransitionTime(unix int64) string {
+	t := time.Unix(unix, 0).UTC()
+	if t.Year() < 0 || t.Year() > 9999 {
+		return strconv.FormatInt(unix, 10)
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+// formatTZifOffset formats a UTC offset like "UTC+05:41:16", with seconds only if there are any.
+func formatTZifOffset(offset int32) string {
+	formatted := formatUTCOffset(int(offset))
+	if seconds := offset % 60; seconds != 0 {
+		formatted += fmt.Sprintf(":%02d", max(seconds, -seconds))
+	}
+	return formatted
+}
+
+// formatHeader formats the counts of a TZif header.
+func formatHeader(h tzif.Header) string {
+	return fmt.Sprintf("isutcnt=%d isstdcnt=%d leapcnt=%d timecnt=%d typecnt=%d charcnt=%d",
+		h.IsUTCCount, h.IsStdCount, h.LeapCount, h.TimeCount, h.TypeCount, h.CharCount)
+}
+
+// inspectionSection is a titled table of an inspection report.
+type inspectionSection struct {
+	title   string
+	key     string // The key of the section in JSON output
+	columns []table.Column
+	rows    []table.Row
+}
+
+// inspectionSections returns the local time types, transitions and leap seconds of a TZif file as tables.
+func inspectionSections(file *tzif.File) []inspectionSection {
+	yesNo := map[bool]string{true: "yes", false: "no"}
+
+	types := inspectionSection{
+		title:   "Local time types",
+		key:     "types",
+		columns: []table.Column{{Title: "#"}, {Title: "Offset"}, {Title: "DST"}, {Title: "Abbr"}, {Title: "Std/Wall"}, {Title: "UT/Local"}},
+	}
+	for i, t := range file.Types {
+		stdWall, utLocal := "wall", "local"
+		if t.IsStd {
+			stdWall = "std"
+		}
+		if t.IsUT {
+			utLocal = "UT"
+		}
+		types.rows = append(types.rows, table.Row{
+			strconv.Itoa(i), formatTZifOffset(t.Offset), yesNo[t.IsDST], t.Abbreviation, stdWall, utLocal,
+		})
+	}
+
+	transitions := inspectionSection{
+		title:   "Transitions",
+		key:     "transitions",
+		columns: []table.Column{{Title: "Time (UTC)"}, {Title: "Type"}, {Title: "Offset"}, {Title: "DST"}, {Title: "Abbr"}},
+	}
+	for _, transition := range file.Transitions {
+		t := file.Types[transition.Type]
+		transitions.rows = append(transitions.rows, table.Row{
+			formatTransitionTime(transition.Time), strconv.Itoa(transition.Type),
+			formatTZifOffset(t.Offset), yesNo[t.IsDST], t.Abbreviation,
+		})
+	}
+
+	leapSeconds := inspectionSection{
+		title:   "Leap seconds",
+		key:     "leapSeconds",
+		columns: []table.Column{{Title: "Time (UTC)"}, {Title: "Correction"}},
+	}
+	for _, leap := range file.LeapSeconds {
+		leapSeconds.rows = append(leapSeconds.rows, table.Row{formatTransitionTime(leap.Time), strconv.Itoa(int(leap.Correction))})
+	}
+	return []inspectionSection{types, transitions, leapSeconds}
+}
+
+// writeInspection writes a readable report of a TZif file and its likely zones.
+func writeInspection(w io.Writer, path string, file *tzif.File, matches []zoneMatch) {
+	fmt.Fprintf(w, "File:      %v\n", path)
+	fmt.Fprintf(w, "Version:   %v\n", file.Version)
+	if file.Version > 1 {
+		fmt.Fprintf(w, "V1 header: %v\n", formatHeader(file.V1Header))
+	}
+	fmt.Fprintf(w, "Header:    %v\n", formatHeader(file.Header))
+	if file.Version > 1 {
+		fmt.Fprintf(w, "Footer:    %v\n", file.Footer)
+	}
+	if len(matches) == 0 {
+		fmt.Fprintln(w, "Zone:      no known zone matches")
+	}
+	for i, match := range matches {
+		label := "Zone:     "
+		if i > 0 {
+			label = "          "
+		}
+		fmt.Fprintf(w, "%v %v (%.0f%% match)\n", label, match.Zone, match.Score*100)
+	}
+
+	for _, section := range inspectionSections(file) {
+		fmt.Fprintf(w, "\n%v (%d):\n", section.title, len(section.rows))
+		if len(section.rows) > 0 {
+			writePlainTable(w, section.columns, section.rows)
+		}
+	}
+}
+
+// writeInspectionJSON writes the report of a TZif file as a JSON object.
+func writeInspectionJSON(w io.Writer, path string, file *tzif.File, matches []zoneMatch) {
+	report := map[string]any{
+		"file":    path,
+		"version": file.Version,
+		"header":  formatHeader(file.Header),
+		"footer":  file.Footer,
+		"matches": matches,
+	}
+	for _, section := range inspectionSections(file) {
+		objects := make([]map[string]string, len(section.rows))
+		for i, row := range section.rows {
+			objects[i] = map[string]string{}
+			for j, column := range section.columns {
+				objects[i][column.Title] = row[j]
+			}
+		}
+		report[section.key] = objects
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.Encode(report)
+}
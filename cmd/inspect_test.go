@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/kritibb/ktz/tzif"
+)
+
+func TestMatchZones(t *testing.T) {
+	withZoneSources(t, embeddedZoneSource())
+	for _, zone := range []string{"Asia/Kathmandu", "America/New_York", "Australia/Lord_Howe"} {
+		data, _, _ := readZoneData(zone)
+		file, err := tzif.Parse(data)
+		if err != nil {
+			t.Fatalf("tzif.Parse(%v) returned error %v", zone, err)
+		}
+		matches := matchZones(data, file, 3)
+		found := false
+		for _, match := range matches {
+			found = found || (match.Zone == zone && match.Score == 1)
+		}
+		if !found {
+			t.Fatalf("matchZones(%v) = %v, want an exact match", zone, matches)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	withZoneSources(t, embeddedZoneSource())
+	parse := func(zone string) *tzif.File {
+		data, _, _ := readZoneData(zone)
+		file, _ := tzif.Parse(data)
+		return file
+	}
+	berlin, paris, kathmandu := parse("Europe/Berlin"), parse("Europe/Paris"), parse("Asia/Kathmandu")
+	if got := similarity(berlin, berlin); got != 1 {
+		t.Fatalf("similarity(Berlin, Berlin) = %v, want 1", got)
+	}
+	if got := similarity(berlin, kathmandu); got != 0 {
+		t.Fatalf("similarity(Berlin, Kathmandu) = %v, want 0", got)
+	}
+	// Berlin and Paris share their footer and the EU transitions since 1996
+	if got := similarity(berlin, paris); got <= 0.2 || got >= 1 {
+		t.Fatalf("similarity(Berlin, Paris) = %v, want between 0.2 and 1", got)
+	}
+}
+
+func TestWriteInspection(t *testing.T) {
+	withZoneSources(t, embeddedZoneSource())
+	data, _, _ := readZoneData("Asia/Kathmandu")
+	file, _ := tzif.Parse(data)
+	var b bytes.Buffer
+	writeInspection(&b, "Kathmandu", file, []zoneMatch{{Zone: "Asia/Kathmandu", Score: 1}})
+	for _, want := range []string{
+		"Footer:    <+0545>-5:45",
+		"Zone:      Asia/Kathmandu (100% match)",
+		"UTC+05:41:16",
+		"1985-12-31 18:30:00",
+		"Leap seconds (0):",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("writeInspection() output lacks %q:\n%v", want, b.String())
+		}
+	}
+}
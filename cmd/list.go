@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// ListOptions holds the filters and options of the `list` command.
+type ListOptions struct {
+	Country string // Only entries of this country, by name or code
+	Offset  string // Only entries at this UTC offset now, like +05:45
+	Region  string // Only entries with a zone in this region, like Europe
+	DST     bool   // Only entries with a zone observing DST
+	Sort    string // Sort by name, offset, country or region
+	Output  string // table, plain, json or csv; empty means the configured output
+}
+
+// listEntry is an entry of a built-in dataset with what its filters and sorting need.
+type listEntry struct {
+	name      string
+	countries []string // The countries it belongs to
+	zones     []string // Its timezones, the main one first
+	offsets   []int    // The UTC offsets it matches, in seconds; the first one is shown
+	dst       bool     // Whether one of its zones observes DST
+	row       table.Row
+}
+
+// listDatasets are the datasets `ktz list` lists, with their columns and entries.
+var listDatasets = map[string]struct {
+	columns []table.Column
+	entries func(r *Resolver, now time.Time) []listEntry
+}{
+	"countries": {
+		columns: []table.Column{
+			{Title: "Name", Width: 36},
+			{Title: "Alpha-2", Width: 8},
+			{Title: "Alpha-3", Width: 8},
+			{Title: "Zones", Width: 6},
+			{Title: "Primary Zone", Width: 32},
+			{Title: "Offset", Width: 10},
+			{Title: "DST", Width: 4},
+		},
+		entries: countryEntries,
+	},
+	"cities": {
+		columns: []table.Column{
+			{Title: "Name", Width: 28},
+			{Title: "Country", Width: 32},
+			{Title: "TimeZone", Width: 32},
+			{Title: "Offset", Width: 10},
+			{Title: "DST", Width: 4},
+		},
+		entries: cityEntries,
+	},
+	"zones": {
+		columns: []table.Column{
+			{Title: "Name", Width: 36},
+			{Title: "Region", Width: 12},
+			{Title: "Offset", Width: 10},
+			{Title: "Abbr.", Width: 8},
+			{Title: "DST", Width: 4},
+			{Title: "Country", Width: 32},
+		},
+		entries: zoneEntries,
+	},
+	"abbreviations": {
+		columns: []table.Column{
+			{Title: "Name", Width: 8},
+			{Title: "TimeZone", Width: 32},
+			{Title: "Offset", Width: 10},
+			{Title: "Kind", Width: 10},
+			{Title: "DST", Width: 4},
+		},
+		entries: abbreviationEntries,
+	},
+}
+
+// zoneState returns the UTC offset of a timezone at 'now', its abbreviation then and whether
+// it observes DST, i.e. its current rules have DST.
+func zoneState(tz string, now time.Time) (int, string, bool) {
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return 0, "", false
+	}
+	abbreviation, offset := now.In(loc).Zone()
+	rules := zoneDSTRules(tz)
+	return offset, abbreviation, rules != "none" && rules != "unknown"
+}
+
+// yesNo formats a boolean for a table.
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
+func countryEntries(r *Resolver, now time.Time) []listEntry {
+	var entries []listEntry
+	for _, country := range sortedKeys(tzdata.CountryToIanaTimezone) {
+		zones := append([]string{primaryZone(country)}, tzdata.CountryToIanaTimezone[country]...)
+		entry := listEntry{name: country, countries: []string{country}, zones: zones}
+		for _, tz := range zones {
+			offset, _, dst := zoneState(tz, now)
+			entry.offsets = append(entry.offsets, offset)
+			entry.dst = entry.dst || dst
+		}
+		alpha2, alpha3, _ := strings.Cut(countryCodes(country), ", ")
+		entry.row = table.Row{country, alpha2, alpha3, fmt.Sprint(len(zones) - 1), zones[0], formatUTCOffset(entry.offsets[0]), yesNo(entry.dst)}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func cityEntries(r *Resolver, now time.Time) []listEntry {
+	var entries []listEntry
+	for _, city := range sortedKeys(tzdata.CityToIanaTimezone) {
+		val := tzdata.CityToIanaTimezone[city]
+		country := canonicalCountry(val["country"])
+		offset, _, dst := zoneState(val["tz"], now)
+		entries = append(entries, listEntry{
+			name:      city,
+			countries: []string{country},
+			zones:     []string{val["tz"]},
+			offsets:   []int{offset},
+			dst:       dst,
+			row:       table.Row{city, country, val["tz"], formatUTCOffset(offset), yesNo(dst)},
+		})
+	}
+	return entries
+}
+
+func zoneEntries(r *Resolver, now time.Time) []listEntry {
+	countries := map[string][]string{}
+	for _, country := range sortedKeys(tzdata.CountryToIanaTimezone) {
+		for _, tz := range tzdata.CountryToIanaTimezone[country] {
+			countries[tz] = append(countries[tz], country)
+		}
+	}
+	var entries []listEntry
+	for _, tz := range knownZones() {
+		offset, abbreviation, dst := zoneState(tz, now)
+		entries = append(entries, listEntry{
+			name:      tz,
+			countries: countries[tz],
+			zones:     []string{tz},
+			offsets:   []int{offset},
+			dst:       dst,
+			row:       table.Row{tz, zoneRegion(tz), formatUTCOffset(offset), abbreviation, yesNo(dst), strings.Join(countries[tz], ", ")},
+		})
+	}
+	return entries
+}
+
+func abbreviationEntries(r *Resolver, now time.Time) []listEntry {
+	names := map[string]bool{}
+	for abbreviation := range tzdata.AbbToIanaTimezone {
+		names[abbreviation] = true
+	}
+	for abbreviation := range r.abbreviations {
+		names[strings.ToUpper(abbreviation)] = true
+	}
+	var entries []listEntry
+	for _, name := range sortedKeys(names) {
+		abbr, _ := r.abbreviationMeaning(name)
+		tz := abbr.Zone
+		if tz == "" {
+			tz = "UTC"
+		}
+		liveOffset, _, dst := zoneState(tz, now)
+		kind, offset := "standard", abbr.Offset
+		switch {
+		case abbr.Generic:
+			// a generic abbreviation like ET is at the offset of its zone
+			kind, offset = "generic", liveOffset
+		case abbr.DST:
+			kind = "daylight"
+		}
+		entries = append(entries, listEntry{
+			name:      name,
+			countries: []string{zoneCountry(tz)},
+			zones:     []string{tz},
+			offsets:   []int{offset},
+			dst:       dst,
+			row:       table.Row{name, tz, formatUTCOffset(offset), kind, yesNo(dst)},
+		})
+	}
+	return entries
+}
+
+// filterEntries returns the entries matching the filters of 'opts'. An entry matches the
+// country, offset and region filters if any of its countries, offsets or zones does.
+func filterEntries(entries []listEntry, opts ListOptions) ([]listEntry, error) {
+	var country string
+	if opts.Country != "" {
+		if country = countryOfCode(opts.Country); country == "" {
+			country = canonicalCountry(opts.Country)
+		}
+		if _, ok := tzdata.CountryToIanaTimezone[country]; !ok {
+			return nil, fmt.Errorf("Country '%s' not found!", opts.Country)
+		}
+	}
+	offset, hasOffset := 0, opts.Offset != ""
+	if hasOffset {
+		var ok bool
+		if offset, ok = parseUTCOffset(opts.Offset); !ok {
+			return nil, fmt.Errorf("Invalid offset '%s', use e.g. +05:45 or -3.", opts.Offset)
+		}
+	}
+	var filtered []listEntry
+	for _, entry := range entries {
+		switch {
+		case country != "" && !containsFunc(entry.countries, func(c string) bool { return c == country }):
+		case hasOffset && !containsFunc(entry.offsets, func(o int) bool { return o == offset }):
+		case opts.Region != "" && !containsFunc(entry.zones, func(tz string) bool { return strings.EqualFold(zoneRegion(tz), opts.Region) }):
+		case opts.DST && !entry.dst:
+		default:
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+// containsFunc reports whether some value of 's' satisfies 'f'.
+func containsFunc[T any](s []T, f func(T) bool) bool {
+	for _, v := range s {
+		if f(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortEntries sorts the entries by name, offset, country or region, then by name.
+func sortEntries(entries []listEntry, by string) error {
+	var less func(a, b listEntry) bool
+	switch by {
+	case "", "name":
+		less = func(a, b listEntry) bool { return false }
+	case "offset":
+		less = func(a, b listEntry) bool { return a.offsets[0] < b.offsets[0] }
+	case "country":
+		less = func(a, b listEntry) bool { return firstOf(a.countries) < firstOf(b.countries) }
+	case "region":
+		less = func(a, b listEntry) bool { return zoneRegion(a.zones[0]) < zoneRegion(b.zones[0]) }
+	default:
+		return fmt.Errorf("Invalid sort '%s', use name, offset, country or region.", by)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if less(entries[i], entries[j]) || less(entries[j], entries[i]) {
+			return less(entries[i], entries[j])
+		}
+		return entries[i].name < entries[j].name
+	})
+	return nil
+}
+
+// firstOf returns the first of some strings, or "" if there are none.
+func firstOf(s []string) string {
+	if len(s) == 0 {
+		return ""
+	}
+	return s[0]
+}
+
+// List prints a built-in dataset, "countries", "cities", "zones" or "abbreviations",
+// filtered and sorted as given by 'opts', with the current UTC offsets.
+func List(r *Resolver, dataset string, opts ListOptions) error {
+	list, ok := listDatasets[dataset]
+	if !ok {
+		return fmt.Errorf("Unknown list '%s', use countries, cities, zones or abbreviations.", dataset)
+	}
+	entries, err := filterEntries(list.entries(r, time.Now()), opts)
+	if err != nil {
+		return err
+	}
+	if err := sortEntries(entries, opts.Sort); err != nil {
+		return err
+	}
+	output := opts.Output
+	if output == "" {
+		output = config.Output
+	}
+	switch output {
+	case "table", "plain", "json", "csv":
+	default:
+		return fmt.Errorf("Invalid output '%s', use table, plain, json or csv.", output)
+	}
+	rows := make([]table.Row, len(entries))
+	for i, entry := range entries {
+		rows[i] = entry.row
+	}
+	m := initialModel(tableView)
+	m.table.SetHeight(len(rows))
+	renderTable(output, fmt.Sprintf("%d %v:", len(rows), dataset), m, list.columns, rows)
+	return nil
+}
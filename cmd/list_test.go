@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+)
+
+func TestFilterEntries(t *testing.T) {
+	now := time.Date(2026, time.January, 15, 12, 0, 0, 0, time.UTC)
+	r := DefaultResolver()
+	tests := []struct {
+		name    string
+		entries []listEntry
+		opts    ListOptions
+		want    string // an entry expected among the results
+		notWant string // an entry not expected among them
+	}{
+		{"country code", countryEntries(r, now), ListOptions{Country: "NP"}, "Nepal", "India"},
+		{"country alias", cityEntries(r, now), ListOptions{Country: "UK"}, "London", "Paris"},
+		{"offset", countryEntries(r, now), ListOptions{Offset: "+5:45"}, "Nepal", "India"},
+		{"offset of any zone", countryEntries(r, now), ListOptions{Offset: "-08:00"}, "United States of America", "Nepal"},
+		{"region", zoneEntries(r, now), ListOptions{Region: "europe"}, "Europe/Berlin", "Asia/Kathmandu"},
+		{"dst", cityEntries(r, now), ListOptions{DST: true}, "Berlin", "Kathmandu"},
+		{"zones of a country", zoneEntries(r, now), ListOptions{Country: "Nepal"}, "Asia/Kathmandu", "Asia/Kolkata"},
+		{"abbreviations of an offset", abbreviationEntries(r, now), ListOptions{Offset: "-05:00"}, "EST", "PST"},
+		{"combined", cityEntries(r, now), ListOptions{Region: "America", DST: true, Offset: "-7"}, "Denver", "Phoenix"},
+	}
+	for _, test := range tests {
+		entries, err := filterEntries(test.entries, test.opts)
+		if err != nil {
+			t.Fatalf("%v: `filterEntries` failed: %v", test.name, err)
+		}
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.name)
+		}
+		if !slices.Contains(names, test.want) || slices.Contains(names, test.notWant) {
+			t.Fatalf("%v: `filterEntries(%+v)` = %v, want %v and not %v", test.name, test.opts, names, test.want, test.notWant)
+		}
+	}
+}
+
+func TestFilterEntriesErrors(t *testing.T) {
+	tests := []ListOptions{
+		{Country: "Atlantis"},
+		{Offset: "5:45"},
+		{Offset: "+25"},
+	}
+	for _, opts := range tests {
+		if _, err := filterEntries(nil, opts); err == nil {
+			t.Fatalf("`filterEntries(%+v)` succeeded, want an error", opts)
+		}
+	}
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := func() []listEntry {
+		return []listEntry{
+			{name: "Kathmandu", countries: []string{"Nepal"}, zones: []string{"Asia/Kathmandu"}, offsets: []int{20700}},
+			{name: "Berlin", countries: []string{"Germany"}, zones: []string{"Europe/Berlin"}, offsets: []int{3600}},
+			{name: "Denver", countries: []string{"USA"}, zones: []string{"America/Denver"}, offsets: []int{-25200}},
+			{name: "Amsterdam", countries: []string{"Netherlands"}, zones: []string{"Europe/Amsterdam"}, offsets: []int{3600}},
+		}
+	}
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{"", []string{"Amsterdam", "Berlin", "Denver", "Kathmandu"}},
+		{"name", []string{"Amsterdam", "Berlin", "Denver", "Kathmandu"}},
+		{"offset", []string{"Denver", "Amsterdam", "Berlin", "Kathmandu"}},
+		{"country", []string{"Berlin", "Kathmandu", "Amsterdam", "Denver"}},
+		{"region", []string{"Denver", "Kathmandu", "Amsterdam", "Berlin"}},
+	}
+	for _, test := range tests {
+		sorted := entries()
+		if err := sortEntries(sorted, test.by); err != nil {
+			t.Fatalf("`sortEntries(%v)` failed: %v", test.by, err)
+		}
+		var names []string
+		for _, entry := range sorted {
+			names = append(names, entry.name)
+		}
+		if !slices.Equal(names, test.want) {
+			t.Fatalf("`sortEntries(%v)` = %v, want %v", test.by, names, test.want)
+		}
+	}
+	if err := sortEntries(entries(), "size"); err == nil {
+		t.Fatalf("`sortEntries(size)` succeeded, want an error")
+	}
+}
+
+func TestCountryEntries(t *testing.T) {
+	now := time.Date(2026, time.July, 1, 12, 0, 0, 0, time.UTC)
+	for _, entry := range countryEntries(DefaultResolver(), now) {
+		if entry.name != "United States of America" {
+			continue
+		}
+		want := table.Row{"United States of America", "US", "USA", entry.row[3], "America/New_York", "UTC-04:00", "yes"}
+		if !slices.Equal(entry.row, want) {
+			t.Fatalf("`countryEntries` row of the USA = %v, want %v", entry.row, want)
+		}
+		return
+	}
+	t.Fatalf("`countryEntries` has no United States of America")
+}
+
+func TestWriteCSVTable(t *testing.T) {
+	var buf bytes.Buffer
+	columns := []table.Column{{Title: "Name"}, {Title: "Country"}}
+	writeCSVTable(&buf, columns, []table.Row{{"Kathmandu", "Nepal"}, {"Washington, D.C.", "USA"}})
+	want := "Name,Country\nKathmandu,Nepal\n\"Washington, D.C.\",USA\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("`writeCSVTable` = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// moment is a wall clock time, optionally on a date, in a place, as given by the user
+// like '09:30 Kathmandu', '9:30pm New York', 'friday 17:00 London' or '2026-11-03 14:00 Asia/Kathmandu'.
+type moment struct {
+	date      time.Time    // The date at midnight UTC, or the zero time if no date was given
+	weekday   time.Weekday // The weekday given instead of a date, if 'onWeekday'
+	onWeekday bool
+	tomorrow  bool // 'tomorrow' was given instead of a date
+	hour      int
+	minute    int
+	place     string         // The place as given by the user; empty means the home zone
+	loc       *time.Location // The location of the place
+}
+
+// parseMoment parses a moment like '09:30 Kathmandu'. The date is optional and given first
+// as YYYY-MM-DD, a weekday like 'fri' or 'friday', 'today' or 'tomorrow'; the time is
+// 24-hour ('21:30') or 12-hour ('9:30pm', '9 PM'); the place is anything resolvePlace
+// accepts and defaults to the configured home zone, or the system zone.
+func (r *Resolver) parseMoment(s string) (moment, error) {
+	var m moment
+	fields := strings.Fields(s)
+	if len(fields) > 0 {
+		if date, err := time.Parse("2006-01-02", fields[0]); err == nil {
+			m.date = date
+			fields = fields[1:]
+		} else if weekday, err := parseWeekday(fields[0]); err == nil {
+			m.weekday, m.onWeekday = weekday, true
+			fields = fields[1:]
+		} else if day := strings.ToLower(fields[0]); day == "today" || day == "tomorrow" {
+			m.tomorrow = day == "tomorrow"
+			fields = fields[1:]
+		}
+	}
+	if len(fields) == 0 {
+		return m, fmt.Errorf("Missing time in '%s'.", s)
+	}
+	clock := fields[0]
+	fields = fields[1:]
+	if len(fields) > 0 && (strings.EqualFold(fields[0], "am") || strings.EqualFold(fields[0], "pm")) {
+		clock += fields[0]
+		fields = fields[1:]
+	}
+	var err error
+	if m.hour, m.minute, err = parseClock(clock); err != nil {
+		return m, err
+	}
+	m.place = strings.Join(fields, " ")
+	home := m.place
+	if home == "" {
+		home = r.home
+	}
+	if m.loc, err = r.homeLocation(home); err != nil {
+		return m, err
+	}
+	return m, nil
+}
+
+// parseClock parses a time of day like '21:30', '9:30pm' or '9 PM' into hour and minute.
+func parseClock(clock string) (int, int, error) {
+	invalid := fmt.Errorf("Invalid time '%s', use e.g. 09:30 or 9:30pm.", clock)
+	lower := strings.ToLower(clock)
+	offset := -1
+	if rest, ok := strings.CutSuffix(lower, "am"); ok {
+		lower, offset = rest, 0
+	} else if rest, ok := strings.CutSuffix(lower, "pm"); ok {
+		lower, offset = rest, 12
+	}
+	hourPart, minutePart, hasMinutes := strings.Cut(lower, ":")
+	hour, err := strconv.Atoi(hourPart)
+	if err != nil {
+		return 0, 0, invalid
+	}
+	minute := 0
+	if hasMinutes {
+		if len(minutePart) != 2 {
+			return 0, 0, invalid
+		}
+		if minute, err = strconv.Atoi(minutePart); err != nil || minute > 59 {
+			return 0, 0, invalid
+		}
+	} else if offset < 0 {
+		// a bare number is only a time with am/pm
+		return 0, 0, invalid
+	}
+	if offset >= 0 {
+		if hour < 1 || hour > 12 {
+			return 0, 0, invalid
+		}
+		hour = hour%12 + offset
+	} else if hour > 23 {
+		return 0, 0, invalid
+	}
+	return hour, minute, nil
+}
+
+// instant returns the instant of the moment: on its date if given, otherwise on the date
+// it is at 'now' in the moment's location, the day after for 'tomorrow', or the next day
+// with the moment's weekday (which may be today).
+func (m moment) instant(now time.Time) time.Time {
+	date := m.date
+	if date.IsZero() {
+		date = now.In(m.loc)
+		switch {
+		case m.tomorrow:
+			date = date.AddDate(0, 0, 1)
+		case m.onWeekday:
+			date = date.AddDate(0, 0, (int(m.weekday)-int(date.Weekday())+7)%7)
+		}
+	}
+	return m.on(date.Year(), date.Month(), date.Day())
+}
+
+// next returns the first instant of the moment after 'now': like instant, but a time
+// without a date that has already passed today is the one tomorrow, or next week on a weekday.
+func (m moment) next(now time.Time) time.Time {
+	t := m.instant(now)
+	if !t.After(now) && m.date.IsZero() && !m.tomorrow {
+		date := t.In(m.loc)
+		days := 1
+		if m.onWeekday {
+			days = 7
+		}
+		date = date.AddDate(0, 0, days)
+		return m.on(date.Year(), date.Month(), date.Day())
+	}
+	return t
+}
+
+// on returns the instant of the moment on the given date in the moment's location.
+func (m moment) on(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, m.hour, m.minute, 0, 0, m.loc)
+}
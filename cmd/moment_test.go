@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseClock(t *testing.T) {
+	tests := []struct {
+		given  string
+		hour   int
+		minute int
+	}{
+		{"09:30", 9, 30},
+		{"21:05", 21, 5},
+		{"9:30pm", 21, 30},
+		{"12am", 0, 0},
+		{"12:15PM", 12, 15},
+	}
+	for _, test := range tests {
+		hour, minute, err := parseClock(test.given)
+		if err != nil || hour != test.hour || minute != test.minute {
+			t.Fatalf("`parseClock(%v)=%v, %v, %v`, want %v, %v", test.given, hour, minute, err, test.hour, test.minute)
+		}
+	}
+	for _, given := range []string{"", "9", "24:00", "13pm", "9:3", "9:60", "noon"} {
+		if _, _, err := parseClock(given); err == nil {
+			t.Fatalf("`parseClock(%v)` returned no error", given)
+		}
+	}
+}
+
+func TestParseMoment(t *testing.T) {
+	m, err := DefaultResolver().parseMoment("2026-11-03 9:30 pm New York")
+	if err != nil {
+		t.Fatalf("`parseMoment()` returned error %v", err)
+	}
+	if m.hour != 21 || m.minute != 30 || m.place != "New York" || m.loc.String() != "America/New_York" {
+		t.Fatalf("`parseMoment()` = %+v", m)
+	}
+	if want := time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC); !m.date.Equal(want) {
+		t.Fatalf("`parseMoment()` date = %v, want %v", m.date, want)
+	}
+	if m, err = DefaultResolver().parseMoment("09:30"); err != nil || m.place != "" || !m.date.IsZero() {
+		t.Fatalf("`parseMoment(09:30)` = %+v, %v", m, err)
+	}
+	for _, given := range []string{"", "Kathmandu", "09:30 Xyzzy"} {
+		if _, err := DefaultResolver().parseMoment(given); err == nil {
+			t.Fatalf("`parseMoment(%v)` returned no error", given)
+		}
+	}
+}
+
+func TestMomentNext(t *testing.T) {
+	london, _ := loadLocation("Europe/London")
+	// Thursday 22 October 2026, 18:00 in London
+	now := time.Date(2026, 10, 22, 18, 0, 0, 0, london)
+	tests := []struct {
+		given string
+		want  time.Time
+	}{
+		{"friday 17:00 London", time.Date(2026, 10, 23, 17, 0, 0, 0, london)},
+		{"thu 19:00 London", time.Date(2026, 10, 22, 19, 0, 0, 0, london)},
+		{"thu 17:00 London", time.Date(2026, 10, 29, 17, 0, 0, 0, london)},
+		{"09:00 London", time.Date(2026, 10, 23, 9, 0, 0, 0, london)},
+		{"tomorrow 9am London", time.Date(2026, 10, 23, 9, 0, 0, 0, london)},
+		{"2026-01-01 00:00 London", time.Date(2026, 1, 1, 0, 0, 0, 0, london)},
+	}
+	for _, test := range tests {
+		m, err := DefaultResolver().parseMoment(test.given)
+		if err != nil {
+			t.Fatalf("`parseMoment(%v)` returned error %v", test.given, err)
+		}
+		if got := m.next(now); !got.Equal(test.want) {
+			t.Fatalf("`next(%v)` = %v, want %v", test.given, got, test.want)
+		}
+	}
+}
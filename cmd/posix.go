@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kritibb/ktz/tzif"
+)
+
+// isPOSIXTZ reports whether 'zone' looks like a POSIX TZ string like 'EST5EDT,M3.2.0,M11.1.0'
+// rather than a timezone name like 'Etc/GMT+5' or an abbreviation: TZ strings always have
+// an offset, and a '/' only in their rules, after a ','.
+func isPOSIXTZ(zone string) bool {
+	if !strings.ContainsAny(zone, "0123456789") {
+		return false
+	}
+	slash := strings.IndexByte(zone, '/')
+	return slash < 0 || strings.Contains(zone[:slash], ",")
+}
+
+// posixLocation returns a location applying the rules of a POSIX TZ string for any year.
+func posixLocation(zone string) (*time.Location, error) {
+	tz, err := tzif.ParsePOSIX(zone)
+	if err != nil {
+		return nil, err
+	}
+	return time.LoadLocationFromTZData(zone, tzif.Encode(tz.File()))
+}
+
+// zoneFile returns the parsed TZif data of a timezone like 'Asia/Kathmandu', or of
+// a POSIX TZ string.
+func zoneFile(zone string) (*tzif.File, error) {
+	if isPOSIXTZ(zone) {
+		if tz, err := tzif.ParsePOSIX(zone); err == nil {
+			return tz.File(), nil
+		}
+	}
+	data, _, err := readZoneData(zone)
+	if err != nil {
+		return nil, err
+	}
+	file, err := tzif.Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", zone, err)
+	}
+	return file, nil
+}
+
+// zonePOSIX returns the POSIX TZ string describing the current rules of a timezone
+// like 'Asia/Kathmandu', taken from the footer of its TZif data.
+func zonePOSIX(zone string) (string, error) {
+	file, err := zoneFile(zone)
+	if err != nil {
+		return "", err
+	}
+	if file.Footer == "" {
+		return "", fmt.Errorf("The data of '%s' has no POSIX TZ string.", zone)
+	}
+	return file.Footer, nil
+}
+
+// ShowPOSIX prints the POSIX TZ string of a place, e.g. 'EST5EDT,M3.2.0,M11.1.0' for 'New York',
+// for devices that are configured with a TZ string rather than an IANA timezone.
+func ShowPOSIX(r *Resolver, place string) {
+	location, err := r.resolvePlace(place)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	posix, err := zonePOSIX(location.timezone)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	if config.Output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(map[string]string{"TimeZone": location.timezone, "POSIX TZ": posix})
+		return
+	}
+	fmt.Println(posix)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsPOSIXTZ(t *testing.T) {
+	tests := []struct {
+		given string
+		want  bool
+	}{
+		{given: "EST5EDT,M3.2.0,M11.1.0", want: true},
+		{given: "CET-1CEST,M3.5.0,M10.5.0/3", want: true},
+		{given: "<+0545>-5:45", want: true},
+		{given: "Etc/GMT+5", want: false},
+		{given: "Asia/Kathmandu", want: false},
+		{given: "PST", want: false},
+	}
+	for _, test := range tests {
+		if got := isPOSIXTZ(test.given); got != test.want {
+			t.Fatalf("`isPOSIXTZ(%v)=%v`, want %v", test.given, got, test.want)
+		}
+	}
+}
+
+func TestLoadLocationPOSIX(t *testing.T) {
+	loc, err := loadLocation("CET-1CEST,M3.5.0,M10.5.0/3")
+	if err != nil {
+		t.Fatalf("loadLocation() returned error %v", err)
+	}
+	berlin, _ := loadLocation("Europe/Berlin")
+	for _, instant := range []time.Time{
+		time.Date(2026, 3, 29, 0, 59, 59, 0, time.UTC),
+		time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC),
+		time.Date(2031, 10, 26, 1, 0, 0, 0, time.UTC),
+	} {
+		if got, want := instant.In(loc).Format(time.RFC3339), instant.In(berlin).Format(time.RFC3339); got != want {
+			t.Fatalf("time at %v = %v, want %v", instant, got, want)
+		}
+	}
+	if _, err := loadLocation("CET-1CEST,M3.5.0"); err == nil {
+		t.Fatalf("loadLocation(CET-1CEST,M3.5.0) returned no error")
+	}
+}
+
+func TestZonePOSIX(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{given: "Asia/Kathmandu", want: "<+0545>-5:45"},
+		{given: "America/New_York", want: "EST5EDT,M3.2.0,M11.1.0"},
+		{given: "Europe/Berlin", want: "CET-1CEST,M3.5.0,M10.5.0/3"},
+		{given: "EST5EDT,M3.2.0/2,M11.1.0/2:00", want: "EST5EDT,M3.2.0,M11.1.0"},
+	}
+	for _, test := range tests {
+		got, err := zonePOSIX(test.given)
+		if err != nil || got != test.want {
+			t.Fatalf("`zonePOSIX(%v)=%v, %v`, want %v", test.given, got, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"maps"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// Resolver resolves places, timezones and abbreviations with the aliases and abbreviations
+// of a configuration and the search indexes it owns. It is immutable after NewResolver, so
+// it may be shared by concurrent goroutines, e.g. the handlers of an HTTP server.
+type Resolver struct {
+	aliases       map[string][]string       // The user's aliases, copied from the configuration
+	abbreviations map[string]string         // The user's abbreviations, copied from the configuration
+	hours         map[string]workingHours   // The working hours, copied from the configuration
+	weekends      map[string][]time.Weekday // The weekend days, copied from the configuration
+	holidays      map[string][]holidayRange // The holidays of the configured files, per alpha-2 code or 'all'
+	home          string                    // The configured home place; empty means the system zone
+	output        string                    // The output mode; the user picks among locations only in a table
+	timeLayout    string                    // The layout of the times of the locations found
+	shortLayout   string                    // The layout of converted times, without seconds
+	indexes       map[string]wordIndex
+}
+
+// Location is a place found by a Resolver.
+type Location struct {
+	City        string // The city name, if the place is a city
+	Subdivision string // The state, province or oblast name, if the place is one
+	Country     string // The country name, if known
+	TimeZone    string // The timezone name (e.g. Asia/Kathmandu) or a POSIX TZ string
+}
+
+// NewResolver returns a resolver of the aliases, abbreviations, working hours, holidays and
+// output settings of 'cfg'. Later changes to 'cfg' don't affect it.
+func NewResolver(cfg Config) *Resolver {
+	r := &Resolver{
+		aliases:       make(map[string][]string, len(cfg.Aliases)),
+		abbreviations: make(map[string]string, len(cfg.Abbreviations)),
+		hours:         maps.Clone(cfg.Hours),
+		weekends:      maps.Clone(cfg.Weekends),
+		holidays:      maps.Clone(cfg.holidays),
+		home:          cfg.Home,
+		output:        cfg.Output,
+		timeLayout:    cfg.timeLayout(),
+		shortLayout:   cfg.shortTimeLayout(),
+		indexes:       map[string]wordIndex{},
+	}
+	for alias, places := range cfg.Aliases {
+		r.aliases[alias] = append([]string(nil), places...)
+	}
+	for abbreviation, tz := range cfg.Abbreviations {
+		r.abbreviations[abbreviation] = tz
+	}
+	for kind, index := range builtinIndexes() {
+		// the built-in indexes are read-only, so every resolver shares them
+		r.indexes[kind] = index
+	}
+	aliases := newtrie()
+	for alias := range r.aliases {
+		aliases.insertWord(alias, alias)
+	}
+	r.indexes[kindAlias] = aliases
+	abbreviations := newtrie()
+	for abbreviation := range tzdata.AbbToIanaTimezone {
+		abbreviations.insertWord(abbreviation, abbreviation)
+	}
+	for abbreviation := range r.abbreviations {
+		abbreviations.insertWord(abbreviation, abbreviation)
+	}
+	r.indexes[kindAbbreviation] = abbreviations
+	return r
+}
+
+// DefaultResolver returns a new resolver of the configuration loaded by LoadConfig. It
+// builds the indexes of the aliases and abbreviations, so it's built once per run and
+// passed to the commands.
+func DefaultResolver() *Resolver {
+	return NewResolver(config)
+}
+
+// Resolve resolves a place to a single location without asking the user, like the
+// places of `ktz diff`: an alias of a single place, a timezone, an abbreviation, a city,
+// a country or a subdivision (name, prefix or code).
+func (r *Resolver) Resolve(place string) (Location, error) {
+	location, err := r.resolvePlace(place)
+	if err != nil {
+		return Location{}, err
+	}
+	return Location{City: location.city, Subdivision: location.subdivision, Country: location.country, TimeZone: location.timezone}, nil
+}
+
+// index returns the index of the entities of the given kind.
+func (r *Resolver) index(kind string) wordIndex {
+	return r.indexes[kind]
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestResolverParallel(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Aliases = map[string][]string{"berlin-office": {"Europe/Berlin"}, "apac": {"Tokyo", "Singapore"}}
+	cfg.Abbreviations = map[string]string{"IST": "Asia/Kolkata"}
+	r := NewResolver(cfg)
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{"Kathmandu", "Asia/Kathmandu"},
+		{"berlin office", "Europe/Berlin"},
+		{"IST", "Asia/Kolkata"},
+		{"NPL", "Asia/Kathmandu"},
+		{"US-CA", "America/Los_Angeles"},
+		{"Asia/Tokyo", "Asia/Tokyo"},
+	}
+	// run with -race: the lookups share the resolver, its indexes and the zone cache
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		for _, test := range tests {
+			wg.Add(1)
+			go func(given, want string) {
+				defer wg.Done()
+				location, err := r.Resolve(given)
+				if err != nil || location.TimeZone != want {
+					t.Errorf("`Resolve(%v)` = %+v, %v, want %v", given, location, err, want)
+				}
+				if results := r.search(given, 5); len(results) == 0 {
+					t.Errorf("`search(%v, 5)` found nothing", given)
+				}
+			}(test.given, test.want)
+		}
+	}
+	wg.Wait()
+}
+
+func TestResolverIsImmutable(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Aliases = map[string][]string{"berlin-office": {"Europe/Berlin"}}
+	r := NewResolver(cfg)
+	cfg.Aliases["berlin-office"][0] = "Asia/Tokyo"
+	cfg.Aliases["tokyo-office"] = []string{"Asia/Tokyo"}
+	if location, err := r.Resolve("berlin-office"); err != nil || location.TimeZone != "Europe/Berlin" {
+		t.Fatalf("`Resolve(berlin-office)` = %+v, %v after changing the configuration, want Europe/Berlin", location, err)
+	}
+	if _, _, ok := r.findAlias("tokyo-office"); ok {
+		t.Fatal("`findAlias(tokyo-office)` found an alias added after NewResolver")
+	}
+
+	// the clock and working hours are the resolver's, not those of the configuration in use
+	defer func(cfg Config) { config = cfg }(config)
+	config = defaultConfig()
+	cfg.Clock = 24
+	cfg.Hours = map[string]workingHours{"default": {8 * 60, 12 * 60}}
+	r = NewResolver(cfg)
+	cfg.Hours["default"] = defaultWorkingHours
+	now := time.Date(2026, time.July, 1, 18, 30, 0, 0, time.UTC)
+	if got, err := r.formatTime("UTC", now); err != nil || got != "Wed, 01 Jul 2026 18:30:00" {
+		t.Fatalf("`formatTime(UTC)` = %q, %v, want the 24 hour clock of the resolver", got, err)
+	}
+	if hours, _ := r.locationSchedule(locationInfo{timezone: "UTC"}); hours != (workingHours{8 * 60, 12 * 60}) {
+		t.Fatalf("`locationSchedule(UTC)` = %v, want the hours of the resolver", hours)
+	}
+}
+
+func TestPickerSelection(t *testing.T) {
+	r := NewResolver(defaultConfig())
+	tests := []struct {
+		items []string
+		want  locationInfo
+	}{
+		{[]string{"London", "Los Angeles"}, locationInfo{city: "London", country: "United Kingdom", timezone: "Europe/London"}},
+		{[]string{"Asia/Kathmandu"}, locationInfo{timezone: "Asia/Kathmandu"}},
+		{[]string{"New South Wales"}, subdivisionLocation("AU-NSW")},
+	}
+	for _, test := range tests {
+		m := initialModel(listView)
+		m.resolver = r
+		items := make([]list.Item, len(test.items))
+		for i, name := range test.items {
+			items[i] = item(name)
+		}
+		m.list.SetItems(items)
+		final, _ := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+		if got := final.(model).selected; got != test.want {
+			t.Fatalf("picking %v selected %+v, want %+v", test.items[0], got, test.want)
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// kindLink is the kind of the zones linked to the zone of a reverse lookup, like Asia/Calcutta
+// for Asia/Kolkata.
+const kindLink = "link"
+
+// parseUTCOffset parses a UTC offset like '+0545', '+05:45', '-3' or 'UTC+5:45' into seconds.
+func parseUTCOffset(s string) (int, bool) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	for _, prefix := range []string{"UTC", "GMT"} {
+		s = strings.TrimPrefix(s, prefix)
+	}
+	if len(s) < 2 || (s[0] != '+' && s[0] != '-') {
+		return 0, false
+	}
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	hourPart, minutePart, hasColon := strings.Cut(s[1:], ":")
+	if !hasColon && len(hourPart) > 2 {
+		hourPart, minutePart = hourPart[:len(hourPart)-2], hourPart[len(hourPart)-2:]
+	}
+	hours, err := strconv.Atoi(hourPart)
+	if err != nil || len(hourPart) > 2 || hours > 14 {
+		return 0, false
+	}
+	minutes := 0
+	if minutePart != "" || hasColon {
+		if minutes, err = strconv.Atoi(minutePart); err != nil || len(minutePart) != 2 || minutes > 59 {
+			return 0, false
+		}
+	}
+	return sign * (hours*3600 + minutes*60), true
+}
+
+// zoneLinks groups the known zones by their TZif data: zones linked to each other, like
+// Asia/Kolkata and Asia/Calcutta, have identical data. Each zone maps to its group, sorted.
+func zoneLinks() map[string][]string {
+	groups := map[string][]string{}
+	for _, zone := range knownZones() {
+		data, _, err := readZoneData(zone)
+		if err != nil {
+			continue
+		}
+		groups[string(data)] = append(groups[string(data)], zone)
+	}
+	links := map[string][]string{}
+	for _, zones := range groups {
+		for _, zone := range zones {
+			links[zone] = zones
+		}
+	}
+	return links
+}
+
+// zoneDSTRules returns the DST rules of a timezone from its POSIX TZ string, e.g.
+// "M3.2.0,M11.1.0" for America/New_York, or "none" if it has no DST.
+func zoneDSTRules(tz string) string {
+	posix, err := zonePOSIX(tz)
+	if err != nil {
+		return "unknown"
+	}
+	if _, rules, ok := strings.Cut(posix, ","); ok {
+		return rules
+	}
+	return "none"
+}
+
+// countryCodes returns the alpha-2 and alpha-3 codes of a country, e.g. "NP, NPL".
+func countryCodes(country string) string {
+	var codes []string
+	for _, table := range []map[string]string{tzdata.Alpha2ToCountry, tzdata.Alpha3ToCountry} {
+		for _, code := range sortedKeys(table) {
+			if canonicalCountry(table[code]) == country {
+				codes = append(codes, code)
+				break
+			}
+		}
+	}
+	return strings.Join(codes, ", ")
+}
+
+// reverseZone returns the timezone named by 'query' for a reverse lookup: a zone name in
+// any case, an abbreviation like 'IST' or a place like 'Kathmandu'.
+func (r *Resolver) reverseZone(query string) (string, error) {
+	for _, zone := range knownZones() {
+		if strings.EqualFold(zone, query) {
+			return zone, nil
+		}
+	}
+	if tz, ok := r.lookupAbbreviation(query); ok && tz != "" {
+		return tz, nil
+	}
+	location, err := r.resolvePlace(query)
+	if err != nil {
+		return "", fmt.Errorf("Zone '%s' not found!", query)
+	}
+	return location.timezone, nil
+}
+
+// reverseRows returns the rows of a reverse lookup of 'zones': the countries using them
+// with their codes, their known cities and the zones themselves, where 'zone' is the
+// zone looked up and the others are its links (or empty when looking up an offset).
+// Rows are grouped by the DST rules of their zone, so places sharing them are listed together.
+func reverseRows(zones []string, zone string) []table.Row {
+	type row struct {
+		name, kind, code, tz, rules string
+	}
+	var rows []row
+	rules := map[string]string{}
+	inZones := map[string]bool{}
+	for _, tz := range zones {
+		rules[tz] = zoneDSTRules(tz)
+		inZones[tz] = true
+	}
+
+	for _, country := range sortedKeys(tzdata.CountryToIanaTimezone) {
+		// a country using several of the zones has a row per DST rules
+		used := map[string][]string{}
+		for _, tz := range tzdata.CountryToIanaTimezone[country] {
+			if inZones[tz] {
+				used[rules[tz]] = append(used[rules[tz]], tz)
+			}
+		}
+		for _, rule := range sortedKeys(used) {
+			tzs := used[rule]
+			description := tzs[0]
+			if primary := primaryZone(country); len(tzs) > 1 {
+				for _, tz := range tzs {
+					if tz == primary {
+						description = tz
+					}
+				}
+				description = fmt.Sprintf("%v and %d more", description, len(tzs)-1)
+			}
+			rows = append(rows, row{country, kindCountry, countryCodes(country), description, rule})
+		}
+	}
+	for _, city := range sortedKeys(tzdata.CityToIanaTimezone) {
+		val := tzdata.CityToIanaTimezone[city]
+		if inZones[val["tz"]] {
+			country := canonicalCountry(val["country"])
+			code, _, _ := strings.Cut(countryCodes(country), ",")
+			rows = append(rows, row{city, kindCity, code, val["tz"], rules[val["tz"]]})
+		}
+	}
+	for _, tz := range zones {
+		kind := kindZone
+		if zone != "" && tz != zone {
+			kind = kindLink
+		}
+		rows = append(rows, row{tz, kind, "", tz, rules[tz]})
+	}
+
+	// the rows are in kind order already, so a stable sort keeps it within the DST rules
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].rules < rows[j].rules })
+	tableRows := make([]table.Row, len(rows))
+	for i, r := range rows {
+		tableRows[i] = table.Row{r.name, r.kind, r.code, r.tz, r.rules}
+	}
+	return tableRows
+}
+
+// ReverseLookup prints the countries, with their ISO codes, and the known cities using a
+// timezone or its links, or, for an offset like '+0545', those of every zone at that offset
+// now. The DST Rules column tells whether they share DST rules.
+func ReverseLookup(r *Resolver, query string) {
+	var zones []string
+	var title, zone string
+	if offset, ok := parseUTCOffset(query); ok {
+		now := time.Now()
+		for _, tz := range knownZones() {
+			loc, err := loadLocation(tz)
+			if err != nil {
+				continue
+			}
+			if _, o := now.In(loc).Zone(); o == offset {
+				zones = append(zones, tz)
+			}
+		}
+		if len(zones) == 0 {
+			fmt.Printf("\nError: %v\n", fmt.Errorf("No zone is at %v now.", formatUTCOffset(offset)))
+			return
+		}
+		title = fmt.Sprintf("Places at %v now:", formatUTCOffset(offset))
+	} else {
+		var err error
+		if zone, err = r.reverseZone(query); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+		if zones = zoneLinks()[zone]; len(zones) == 0 {
+			zones = []string{zone}
+		}
+		title = fmt.Sprintf("Places using %v:", zone)
+	}
+	columns := []table.Column{
+		{Title: "Name", Width: 32},
+		{Title: "Kind", Width: 8},
+		{Title: "Code", Width: 8},
+		{Title: "TimeZone", Width: 36},
+		{Title: "DST Rules", Width: 24},
+	}
+	runTable(title, initialModel(tableView), columns, reverseRows(zones, zone))
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseUTCOffset(t *testing.T) {
+	tests := []struct {
+		given  string
+		want   int
+		wantOK bool
+	}{
+		{"+0545", 5*3600 + 45*60, true},
+		{"+05:45", 5*3600 + 45*60, true},
+		{"UTC+5:45", 5*3600 + 45*60, true},
+		{"-3", -3 * 3600, true},
+		{"GMT-03:30", -(3*3600 + 30*60), true},
+		{"+14", 14 * 3600, true},
+		{"+15", 0, false},
+		{"+05:5", 0, false},
+		{"0545", 0, false},
+		{"Asia/Kathmandu", 0, false},
+		{"+", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := parseUTCOffset(test.given)
+		if got != test.want || ok != test.wantOK {
+			t.Fatalf("`parseUTCOffset(%v)` = %v, %v, want %v, %v", test.given, got, ok, test.want, test.wantOK)
+		}
+	}
+}
+
+func TestZoneLinks(t *testing.T) {
+	links := zoneLinks()
+	if got := links["Asia/Kolkata"]; !slices.Equal(got, []string{"Asia/Calcutta", "Asia/Kolkata"}) {
+		t.Fatalf("`zoneLinks()[Asia/Kolkata]` = %v, want Asia/Calcutta and Asia/Kolkata", got)
+	}
+	if got := links["Asia/Kathmandu"]; !slices.Contains(got, "Asia/Katmandu") {
+		t.Fatalf("`zoneLinks()[Asia/Kathmandu]` = %v, want Asia/Katmandu among them", got)
+	}
+}
+
+func TestZoneDSTRules(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{"America/New_York", "M3.2.0,M11.1.0"},
+		{"Asia/Kathmandu", "none"},
+		{"Europe/Berlin", "M3.5.0,M10.5.0/3"},
+	}
+	for _, test := range tests {
+		if got := zoneDSTRules(test.given); got != test.want {
+			t.Fatalf("`zoneDSTRules(%v)` = %q, want %q", test.given, got, test.want)
+		}
+	}
+}
+
+func TestReverseRows(t *testing.T) {
+	if got := countryCodes("Russia"); got != "RU, RUS" {
+		t.Fatalf("`countryCodes(Russia)` = %q, want RU, RUS", got)
+	}
+	got := reverseRows([]string{"Asia/Calcutta", "Asia/Kolkata"}, "Asia/Kolkata")
+	want := [][]string{
+		{"India", kindCountry, "IN, IND", "Asia/Kolkata and 1 more", "none"},
+		{"Kolkata", kindCity, "IN", "Asia/Kolkata", "none"},
+		{"Asia/Calcutta", kindLink, "", "Asia/Calcutta", "none"},
+		{"Asia/Kolkata", kindZone, "", "Asia/Kolkata", "none"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("`reverseRows(Asia/Kolkata)` = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("`reverseRows(Asia/Kolkata)` row %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+	// places with DST rules of their own are listed apart
+	rows := reverseRows([]string{"America/Phoenix", "America/Denver"}, "")
+	if rows[0][4] != "M3.2.0,M11.1.0" || rows[len(rows)-1][4] != "none" {
+		t.Fatalf("`reverseRows(Denver, Phoenix)` = %v, want Denver's rules before Phoenix's none", rows)
+	}
+}
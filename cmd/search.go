@@ -0,0 +1,269 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/charmbracelet/bubbles/table"
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// The kinds of entities a search finds, each with its own index.
+const (
+	kindAlias        = "alias"
+	kindCity         = "city"
+	kindCountry      = "country"
+	kindSubdivision  = "subdivision"
+	kindZone         = "zone"
+	kindAbbreviation = "abbr"
+)
+
+// searchKinds are the kinds of entities in the order results of the same rank are listed.
+var searchKinds = []string{kindAlias, kindCity, kindCountry, kindSubdivision, kindZone, kindAbbreviation}
+
+// builtinIndexes are the indexes of the built-in cities, countries, subdivisions and zones
+// by kind, read from the prebuilt tzdata.SearchIndex on first use.
+var builtinIndexes = sync.OnceValue(func() map[string]wordIndex {
+	indexes, err := decodeSearchIndex(tzdata.SearchIndex)
+	if err != nil {
+		// the index is generated with the binary, so this only happens to a broken build
+		return buildIndexes()
+	}
+	return indexes
+})
+
+// buildIndexes builds the tries of the built-in cities, countries, subdivisions and zones by kind,
+// the indexes tzdata.SearchIndex is generated like.
+func buildIndexes() map[string]wordIndex {
+	tries := map[string]*trie{}
+	for _, kind := range []string{kindCity, kindCountry, kindSubdivision, kindZone} {
+		tries[kind] = newtrie()
+	}
+	for city := range tzdata.CityToIanaTimezone {
+		tries[kindCity].insertWord(city, city)
+	}
+	// countries are found by their aliases as well, e.g. 'holland' for Netherlands
+	for alias, country := range tzdata.CountryAliases {
+		tries[kindCountry].insertWord(alias, country)
+	}
+	for country := range tzdata.CountryToIanaTimezone {
+		tries[kindCountry].insertWord(country, country)
+	}
+	for _, val := range tzdata.SubdivisionToIanaTimezone {
+		tries[kindSubdivision].insertWord(val["name"], val["name"])
+	}
+	for _, zone := range knownZones() {
+		// a zone is found by its last part as well, e.g. 'kathmandu' for Asia/Kathmandu
+		tries[kindZone].insertWord(zone[strings.LastIndex(zone, "/")+1:], zone)
+		tries[kindZone].insertWord(zone, zone)
+	}
+	indexes := map[string]wordIndex{}
+	for kind, t := range tries {
+		indexes[kind] = t
+	}
+	return indexes
+}
+
+// searchResult is an entity found by a search.
+type searchResult struct {
+	name string
+	kind string
+	rank int // 0 for exact matches, 1 for the entities they belong to, otherwise 2 + the edit distance to the query
+}
+
+// search searches the cities, countries, subdivisions, zones, abbreviations and the user's
+// aliases at once and returns up to 'limit' results, best first. Exact matches (a name, or
+// a country or subdivision code) come first, followed by the entities they belong to: searching
+// 'kathmandu' finds Kathmandu (city), Nepal (country), Asia/Kathmandu (zone) and NPT (abbr).
+// Other names starting with the query follow, closest first.
+func (r *Resolver) search(query string, limit int) []searchResult {
+	cleaned := cleanWord(query)
+	if cleaned == "" {
+		return nil
+	}
+	ranks := map[[2]string]int{}
+	add := func(kind, name string, rank int) {
+		key := [2]string{kind, name}
+		if previous, ok := ranks[key]; !ok || rank < previous {
+			ranks[key] = rank
+		}
+	}
+	exact := func(kind, name string, rank int) {
+		add(kind, name, rank)
+		for _, related := range r.relatedEntities(kind, name) {
+			add(related[0], related[1], 1)
+		}
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(query))
+	if country := countryOfCode(code); country != "" {
+		exact(kindCountry, country, 0)
+	}
+	if val, ok := tzdata.SubdivisionToIanaTimezone[code]; ok {
+		exact(kindSubdivision, val["name"], 0)
+	}
+	for _, kind := range searchKinds {
+		match, isExact, matches := r.index(kind).matchPrefix(cleaned, limit)
+		if isExact {
+			// a zone found by its last part only belongs to the place named like it
+			rank := 0
+			if kind == kindZone && cleanWord(match) != cleaned {
+				rank = 1
+			}
+			exact(kind, match, rank)
+		}
+		for _, m := range matches {
+			add(kind, m.word, 2+m.distance)
+		}
+	}
+
+	results := make([]searchResult, 0, len(ranks))
+	for key, rank := range ranks {
+		results = append(results, searchResult{kind: key[0], name: key[1], rank: rank})
+	}
+	kindOrder := map[string]int{}
+	for i, kind := range searchKinds {
+		kindOrder[kind] = i
+	}
+	sort.Slice(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.rank != b.rank {
+			return a.rank < b.rank
+		}
+		if a.kind != b.kind {
+			return kindOrder[a.kind] < kindOrder[b.kind]
+		}
+		return a.name < b.name
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// relatedEntities returns the entities an entity belongs to, as kind and name: the country,
+// timezone and abbreviations of a city, the timezones of a country, and so on.
+func (r *Resolver) relatedEntities(kind, name string) [][2]string {
+	var related [][2]string
+	withZone := func(tz string) {
+		related = append(related, [2]string{kindZone, tz})
+		for _, abbreviation := range r.zoneAbbreviations(tz) {
+			related = append(related, [2]string{kindAbbreviation, abbreviation})
+		}
+	}
+	switch kind {
+	case kindCity:
+		val := tzdata.CityToIanaTimezone[name]
+		if country := canonicalCountry(val["country"]); tzdata.CountryToIanaTimezone[country] != nil {
+			related = append(related, [2]string{kindCountry, country})
+		}
+		withZone(val["tz"])
+	case kindCountry:
+		for _, tz := range tzdata.CountryToIanaTimezone[name] {
+			withZone(tz)
+		}
+	case kindSubdivision:
+		if code, ok := findSubdivision(name); ok {
+			val := tzdata.SubdivisionToIanaTimezone[code]
+			related = append(related, [2]string{kindCountry, val["country"]})
+			withZone(val["tz"])
+		}
+	case kindZone:
+		if country := zoneCountry(name); country != "" {
+			related = append(related, [2]string{kindCountry, country})
+		}
+		for _, abbreviation := range r.zoneAbbreviations(name) {
+			related = append(related, [2]string{kindAbbreviation, abbreviation})
+		}
+	case kindAbbreviation:
+		if tz, ok := r.lookupAbbreviation(name); ok && tz != "" {
+			related = append(related, [2]string{kindZone, tz})
+		}
+	}
+	return related
+}
+
+// zoneAbbreviations returns the abbreviations standing for the timezone 'tz', sorted.
+func (r *Resolver) zoneAbbreviations(tz string) []string {
+	var abbreviations []string
+	for _, abbreviation := range sortedKeys(tzdata.AbbToIanaTimezone) {
+		if _, configured := r.abbreviations[abbreviation]; !configured && tzdata.AbbToIanaTimezone[abbreviation].Zone == tz {
+			abbreviations = append(abbreviations, abbreviation)
+		}
+	}
+	for _, abbreviation := range sortedKeys(r.abbreviations) {
+		if r.abbreviations[abbreviation] == tz {
+			abbreviations = append(abbreviations, abbreviation)
+		}
+	}
+	sort.Strings(abbreviations)
+	return abbreviations
+}
+
+// resultTimezone describes the timezones of a search result, e.g. "Asia/Kathmandu" or "6 timezones".
+func (r *Resolver) resultTimezone(result searchResult) string {
+	switch result.kind {
+	case kindAlias:
+		_, places, _ := r.findAlias(result.name)
+		return strings.Join(places, ", ")
+	case kindCity:
+		return tzdata.CityToIanaTimezone[result.name]["tz"]
+	case kindCountry:
+		if zones := tzdata.CountryToIanaTimezone[result.name]; len(zones) != 1 {
+			return fmt.Sprintf("%d timezones", len(zones))
+		}
+		return tzdata.CountryToIanaTimezone[result.name][0]
+	case kindSubdivision:
+		if code, ok := findSubdivision(result.name); ok {
+			return tzdata.SubdivisionToIanaTimezone[code]["tz"]
+		}
+	case kindZone:
+		return result.name
+	case kindAbbreviation:
+		if tz, _ := r.lookupAbbreviation(result.name); tz != "" {
+			return tz
+		}
+		return "UTC"
+	}
+	return ""
+}
+
+// Search prints the cities, countries, subdivisions, zones, abbreviations and aliases
+// matching 'query', best first, with their kind and timezone.
+func Search(r *Resolver, query string, limit int) {
+	results := r.search(query, limit)
+	if len(results) == 0 {
+		fmt.Printf("\nError: %v\n", fmt.Errorf("Nothing found for '%s'!", query))
+		return
+	}
+	columns := []table.Column{
+		{Title: "Name", Width: 32},
+		{Title: "Kind", Width: 12},
+		{Title: "TimeZone", Width: 32},
+	}
+	rows := make([]table.Row, 0, len(results))
+	for _, result := range results {
+		rows = append(rows, table.Row{result.name, result.kind, r.resultTimezone(result)})
+	}
+	runTable(fmt.Sprintf("Results for '%v':", query), initialModel(tableView), columns, rows)
+}
+
+// Lookup prints the current time of whatever 'query' names best: a city or alias, a country
+// or subdivision, a timezone or an abbreviation, e.g. `ktz lookup nepal` or `ktz lookup pst`.
+func Lookup(r *Resolver, query string, opts LookupOptions) {
+	results := r.search(query, 1)
+	if len(results) == 0 {
+		fmt.Printf("\nError: %v\n", fmt.Errorf("Place '%s' not found!", query))
+		return
+	}
+	switch best := results[0]; best.kind {
+	case kindCountry, kindSubdivision:
+		ResolveTimezone(r, "", query, "", opts)
+	case kindZone, kindAbbreviation:
+		ResolveTimezone(r, "", "", best.name, opts)
+	default:
+		ResolveTimezone(r, query, "", "", opts)
+	}
+}
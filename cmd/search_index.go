@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// prebuiltIndex is one section of tzdata.SearchIndex: the front-coded words of one kind,
+// searched in place by binary search over the restart points.
+type prebuiltIndex struct {
+	count    int
+	entries  []byte
+	restarts []byte // Little-endian uint32 offsets into 'entries'
+}
+
+// indexReader reads the uvarints and strings of tzdata.SearchIndex, remembering the first error.
+type indexReader struct {
+	data   []byte
+	offset int
+	err    error
+}
+
+func (r *indexReader) uvarint() int {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(r.data[r.offset:])
+	if n <= 0 || value > uint64(len(r.data)) {
+		r.err = fmt.Errorf("Invalid search index at byte %d.", r.offset)
+		return 0
+	}
+	r.offset += n
+	return int(value)
+}
+
+func (r *indexReader) bytes(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n > len(r.data)-r.offset {
+		r.err = fmt.Errorf("Truncated search index at byte %d.", r.offset)
+		return nil
+	}
+	b := r.data[r.offset : r.offset+n]
+	r.offset += n
+	return b
+}
+
+func (r *indexReader) string() string {
+	return string(r.bytes(r.uvarint()))
+}
+
+// decodeSearchIndex splits a search index in the format of tzdata.SearchIndex into its
+// sections by kind. The words themselves are only decoded when searched.
+func decodeSearchIndex(data []byte) (map[string]wordIndex, error) {
+	if !strings.HasPrefix(string(data), tzdata.SearchIndexMagic) {
+		return nil, fmt.Errorf("Not a search index of version %q.", tzdata.SearchIndexMagic)
+	}
+	r := &indexReader{data: data, offset: len(tzdata.SearchIndexMagic)}
+	indexes := map[string]wordIndex{}
+	for sections := r.uvarint(); sections > 0 && r.err == nil; sections-- {
+		kind := r.string()
+		index := &prebuiltIndex{count: r.uvarint()}
+		index.entries = r.bytes(r.uvarint())
+		index.restarts = r.bytes(4 * ((index.count + tzdata.SearchIndexRestart - 1) / tzdata.SearchIndexRestart))
+		indexes[kind] = index
+	}
+	return indexes, r.err
+}
+
+// restart returns the offset of the entry of the i-th restart point.
+func (x *prebuiltIndex) restart(i int) int {
+	return int(binary.LittleEndian.Uint32(x.restarts[4*i:]))
+}
+
+// entry decodes the entry at 'offset' following the key 'previous'. It returns the key,
+// the original word and the offset of the next entry.
+func (x *prebuiltIndex) entry(offset int, previous string) (string, string, int) {
+	r := &indexReader{data: x.entries, offset: offset}
+	shared := r.uvarint()
+	suffix := r.string()
+	original := r.string()
+	if r.err != nil || shared > len(previous) {
+		// a broken entry ends the search
+		return "", "", len(x.entries)
+	}
+	return previous[:shared] + suffix, original, r.offset
+}
+
+func (x *prebuiltIndex) matchPrefix(prefix string, k int) (string, bool, []wordMatch) {
+	// the last restart point before the first key not less than the prefix
+	restarts := len(x.restarts) / 4
+	block := sort.Search(restarts, func(i int) bool {
+		// restart points share no prefix, so their key is the suffix
+		r := &indexReader{data: x.entries, offset: x.restart(i)}
+		r.uvarint()
+		return string(r.bytes(r.uvarint())) >= prefix
+	})
+	offset := 0
+	if block > 0 {
+		offset = x.restart(block - 1)
+	}
+
+	var exact string
+	var isExact bool
+	closest := newClosestWords(k)
+	key := ""
+	for offset < len(x.entries) {
+		var original string
+		key, original, offset = x.entry(offset, key)
+		if key < prefix {
+			continue
+		}
+		if !strings.HasPrefix(key, prefix) {
+			break
+		}
+		if key == prefix {
+			exact, isExact = original, true
+		}
+		closest.add(wordMatch{word: original, key: key, distance: len(key) - len(prefix)})
+	}
+	return exact, isExact, closest.sorted()
+}
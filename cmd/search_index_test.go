@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// sortedMatches returns the words of an index starting with 'prefix', cleaned and sorted.
+// Cleaning hides which of two words with the same key was kept, like Basse-Terre and Basseterre.
+func sortedMatches(index wordIndex, prefix string) []string {
+	_, _, matches := index.matchPrefix(prefix, 0)
+	words := make([]string, len(matches))
+	for i, match := range matches {
+		words[i] = cleanWord(match.word)
+	}
+	sort.Strings(words)
+	return words
+}
+
+func TestSearchIndexUpToDate(t *testing.T) {
+	prebuilt, err := decodeSearchIndex(tzdata.SearchIndex)
+	if err != nil {
+		t.Fatalf("`decodeSearchIndex(tzdata.SearchIndex)` error: %v", err)
+	}
+	built := buildIndexes()
+	for kind, index := range built {
+		if got, want := sortedMatches(prebuilt[kind], ""), sortedMatches(index, ""); !EqualSlices(got, want) {
+			t.Fatalf("tzdata.SearchIndex has %d words of kind %v, want %d: run `go generate ./tzdata`", len(got), kind, len(want))
+		}
+	}
+}
+
+func TestPrebuiltIndexMatchPrefix(t *testing.T) {
+	prebuilt, err := decodeSearchIndex(tzdata.SearchIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	built := buildIndexes()
+	tests := []struct {
+		kind, prefix string
+		exact        string
+	}{
+		{kindCity, "kathmandu", "Kathmandu"},
+		{kindCity, "newyork", "New York"},
+		{kindCity, "san", ""},
+		{kindCity, "a", ""},
+		{kindCity, "zzz", ""},
+		{kindCountry, "united", ""},
+		{kindCountry, "zimbabwe", "Zimbabwe"},
+		{kindSubdivision, "california", "California"},
+		{kindZone, "kathmandu", "Asia/Kathmandu"},
+		{kindZone, "americaarg", ""},
+	}
+	for _, tt := range tests {
+		exact, isExact, _ := prebuilt[tt.kind].matchPrefix(tt.prefix, 0)
+		if exact != tt.exact || isExact != (tt.exact != "") {
+			t.Fatalf("`matchPrefix(%v)` of %v = %q, %v, want %q", tt.prefix, tt.kind, exact, isExact, tt.exact)
+		}
+		if got, want := sortedMatches(prebuilt[tt.kind], tt.prefix), sortedMatches(built[tt.kind], tt.prefix); !EqualSlices(got, want) {
+			t.Fatalf("`matchPrefix(%v)` of %v = %q, want %q", tt.prefix, tt.kind, got, want)
+		}
+	}
+}
+
+func TestDecodeSearchIndexErrors(t *testing.T) {
+	if _, err := decodeSearchIndex([]byte("KTZI0\x01")); err == nil {
+		t.Fatal("`decodeSearchIndex()` accepted another version")
+	}
+	if _, err := decodeSearchIndex(tzdata.SearchIndex[:len(tzdata.SearchIndex)/2]); err == nil {
+		t.Fatal("`decodeSearchIndex()` accepted a truncated index")
+	}
+}
+
+// The cold benchmarks measure the first search of a run: reading the prebuilt index
+// against building the tries from the tzdata maps.
+func BenchmarkColdSearchPrebuilt(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		indexes, _ := decodeSearchIndex(tzdata.SearchIndex)
+		indexes[kindCity].matchPrefix("kath", 10)
+	}
+}
+
+func BenchmarkColdSearchTrie(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		buildIndexes()[kindCity].matchPrefix("kath", 10)
+	}
+}
+
+func BenchmarkSearchPrebuilt(b *testing.B) {
+	indexes, _ := decodeSearchIndex(tzdata.SearchIndex)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		indexes[kindCity].matchPrefix("kath", 10)
+	}
+}
+
+func BenchmarkSearchTrie(b *testing.B) {
+	indexes := buildIndexes()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		indexes[kindCity].matchPrefix("kath", 10)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+)
+
+// formatResults formats search results like "Kathmandu (city)".
+func formatResults(results []searchResult) []string {
+	formatted := make([]string, len(results))
+	for i, result := range results {
+		formatted[i] = fmt.Sprintf("%v (%v)", result.name, result.kind)
+	}
+	return formatted
+}
+
+func TestSearch(t *testing.T) {
+	withAliases(t, map[string][]string{"apac": {"Tokyo", "Singapore"}})
+	tests := []struct {
+		given string
+		limit int
+		want  []string
+	}{
+		{"kathmandu", 0, []string{"Kathmandu (city)", "Nepal (country)", "Asia/Kathmandu (zone)", "NPT (abbr)"}},
+		{"Nepal", 0, []string{"Nepal (country)", "Asia/Kathmandu (zone)", "NPT (abbr)"}},
+		{"NP", 0, []string{"Nepal (country)", "Asia/Kathmandu (zone)", "NPT (abbr)"}},
+		{"pst", 2, []string{"PST (abbr)", "America/Los_Angeles (zone)"}},
+		{"Asia/Kath", 0, []string{"Asia/Kathmandu (zone)"}},
+		{"US-CA", 2, []string{"California (subdivision)", "United States of America (country)"}},
+		{"APAC", 0, []string{"apac (alias)"}},
+		{"Berl", 2, []string{"Berlin (city)", "Europe/Berlin (zone)"}},
+		{"xyzzyq", 0, []string{}},
+	}
+	for _, tt := range tests {
+		if got := formatResults(DefaultResolver().search(tt.given, tt.limit)); !EqualSlices(got, tt.want) {
+			t.Fatalf("`search(%v, %v)` = %q, want %q", tt.given, tt.limit, got, tt.want)
+		}
+	}
+}
+
+func TestSearchIndexesAreSeparate(t *testing.T) {
+	// a city search doesn't find countries, whatever was searched before
+	r := DefaultResolver()
+	r.search("nepal", 0)
+	if got, err := r.getMatchingLocation("Nepal", ""); err == nil {
+		t.Fatalf("`getMatchingLocation(Nepal, \"\")` = %v, want no city", got)
+	}
+	if got, err := r.getMatchingLocation("", "Kathmandu"); err == nil {
+		t.Fatalf("`getMatchingLocation(\"\", Kathmandu)` = %v, want no country", got)
+	}
+}
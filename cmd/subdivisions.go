@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// findSubdivision returns the ISO 3166-2 code of a subdivision given by its code
+// (e.g. 'US-CA') or its exact name (e.g. 'Western Australia'), ignoring case.
+func findSubdivision(subdivision string) (string, bool) {
+	code := strings.ToUpper(strings.TrimSpace(subdivision))
+	if _, ok := tzdata.SubdivisionToIanaTimezone[code]; ok {
+		return code, true
+	}
+	cleaned := cleanWord(subdivision)
+	for code, val := range tzdata.SubdivisionToIanaTimezone {
+		if cleanWord(val["name"]) == cleaned {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+// subdivisionLocation returns the location of the subdivision with the given code.
+func subdivisionLocation(code string) locationInfo {
+	val := tzdata.SubdivisionToIanaTimezone[code]
+	return locationInfo{subdivision: val["name"], country: val["country"], timezone: val["tz"]}
+}
+
+// zoneSubdivisions returns the names of the subdivisions of a country, grouped by the timezone they use.
+func zoneSubdivisions(country string) map[string][]string {
+	subdivisions := map[string][]string{}
+	for _, val := range tzdata.SubdivisionToIanaTimezone {
+		if val["country"] == country {
+			subdivisions[val["tz"]] = append(subdivisions[val["tz"]], val["name"])
+		}
+	}
+	for _, names := range subdivisions {
+		sort.Strings(names)
+	}
+	return subdivisions
+}
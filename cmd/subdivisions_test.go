@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+func TestSubdivisionData(t *testing.T) {
+	names := map[string]string{}
+	for code, val := range tzdata.SubdivisionToIanaTimezone {
+		if _, err := loadLocation(val["tz"]); err != nil {
+			t.Fatalf("%v: invalid timezone %v", code, val["tz"])
+		}
+		if !slices.Contains(tzdata.CountryToIanaTimezone[val["country"]], val["tz"]) {
+			t.Fatalf("%v: timezone %v is not a timezone of %v", code, val["tz"], val["country"])
+		}
+		if other, ok := names[cleanWord(val["name"])]; ok {
+			t.Fatalf("%v and %v have the same name %v", code, other, val["name"])
+		}
+		names[cleanWord(val["name"])] = code
+	}
+}
+
+func TestGetMatchingLocationSubdivision(t *testing.T) {
+	tests := []struct {
+		given string
+		want  []string
+	}{
+		{given: "US-CA", want: []string{"US-CA"}},
+		{given: "au-wa", want: []string{"AU-WA"}},
+		{given: "Western Australia", want: []string{"Western Australia"}},
+		// the country wins over the US state
+		{given: "Georgia", want: []string{"Georgia"}},
+	}
+	for _, test := range tests {
+		got, err := DefaultResolver().getMatchingLocation("", test.given)
+		if err != nil || !EqualSlices(got, test.want) {
+			t.Fatalf("`getMatchingLocation(\"\", %v)=%v, %v`, want %v", test.given, got, err, test.want)
+		}
+	}
+
+	location, err := DefaultResolver().resolvePlace("US-GA")
+	if err != nil || location.timezone != "America/New_York" || location.subdivision != "Georgia" {
+		t.Fatalf("`resolvePlace(US-GA)=%+v, %v`, want Georgia in America/New_York", location, err)
+	}
+}
+
+func TestNewZoneItems(t *testing.T) {
+	now := time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC)
+	items := newZoneItems("Australia", tzdata.CountryToIanaTimezone["Australia"], now)
+	if len(items) != len(tzdata.CountryToIanaTimezone["Australia"]) {
+		t.Fatalf("newZoneItems returned %d items, want %d", len(items), len(tzdata.CountryToIanaTimezone["Australia"]))
+	}
+	for i := 1; i < len(items); i++ {
+		if items[i-1].(zoneItem).offset > items[i].(zoneItem).offset {
+			t.Fatalf("newZoneItems is not sorted by offset: %v before %v", items[i-1], items[i])
+		}
+	}
+	perth := items[0].(zoneItem)
+	want := zoneItem{zone: "Australia/Perth", city: "Perth", region: "Western Australia", offset: 8 * 3600, localTime: "Wed 08:00 PM"}
+	if perth != want {
+		t.Fatalf("first item = %+v, want %+v", perth, want)
+	}
+
+	m := initialModel(listView)
+	m.list.SetItems(items[:1])
+	if view := m.View(); !strings.Contains(view, "Perth, Western Australia  UTC+08:00  Wed 08:00 PM  (Australia/Perth)") {
+		t.Fatalf("picker view %q does not describe Australia/Perth", view)
+	}
+	if !strings.Contains(perth.FilterValue(), "Western Australia") {
+		t.Fatalf("FilterValue() = %q, want the region to be filterable", perth.FilterValue())
+	}
+}
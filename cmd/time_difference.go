@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// resolvePlace resolves a place to a single location without asking the user.
+// The 'place' parameter can be an alias of a single place, a timezone ('Asia/Kathmandu'
+// or a POSIX TZ string like 'EST5EDT,M3.2.0,M11.1.0'),
+// a zone abbreviation ('PST'), a city or a country (name, prefix or alpha-2/alpha-3 code).
+// If several locations match, the closest one is used.
+func (r *Resolver) resolvePlace(place string) (locationInfo, error) {
+	if alias, places, ok := r.findAlias(place); ok {
+		if len(places) != 1 {
+			return locationInfo{}, fmt.Errorf("Alias '%s' is a group of %d places.", alias, len(places))
+		}
+		return r.resolveBuiltinPlace(places[0])
+	}
+	return r.resolveBuiltinPlace(place)
+}
+
+// resolvePlaces resolves several places like resolvePlace, expanding aliases of a group
+// into their places. Each location keeps the place as given in its 'place' field.
+func (r *Resolver) resolvePlaces(places []string) ([]locationInfo, error) {
+	var locations []locationInfo
+	for _, place := range places {
+		if alias, members, ok := r.findAlias(place); ok && len(members) > 1 {
+			expanded, err := r.expandAlias(alias)
+			if err != nil {
+				return nil, err
+			}
+			locations = append(locations, expanded...)
+			continue
+		}
+		location, err := r.resolvePlace(place)
+		if err != nil {
+			return nil, err
+		}
+		location.place = place
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+// resolveBuiltinPlace resolves a place like resolvePlace, ignoring the user's aliases.
+func (r *Resolver) resolveBuiltinPlace(place string) (locationInfo, error) {
+	var location locationInfo
+	if place == "" {
+		return location, fmt.Errorf("Empty place.")
+	}
+	if strings.Contains(place, "/") || strings.EqualFold(place, "UTC") || isPOSIXTZ(place) {
+		if _, err := loadLocation(place); err == nil {
+			location.timezone = place
+			return location, nil
+		}
+	}
+	if tz, ok := r.lookupAbbreviation(place); ok {
+		location.timezone = tz
+		return location, nil
+	}
+	cities, cityErr := r.getMatchingLocation(place, "")
+	isCity := cityErr == nil
+	if isCity {
+		_, isCity = tzdata.CityToIanaTimezone[cities[0]]
+	}
+	// an exact city name wins, otherwise country codes are checked before city prefixes
+	if isCity && (cleanWord(cities[0]) == cleanWord(place) || !isCountryCode(place)) {
+		val := tzdata.CityToIanaTimezone[cities[0]]
+		location.city = cities[0]
+		location.country = canonicalCountry(val["country"])
+		location.timezone = val["tz"]
+		return location, nil
+	}
+	countries, err := r.getMatchingLocation("", place)
+	if err != nil {
+		return location, fmt.Errorf("Place '%s' not found!", place)
+	}
+	if code, ok := findSubdivision(countries[0]); ok {
+		if _, isCountry := tzdata.CountryToIanaTimezone[countries[0]]; !isCountry {
+			return subdivisionLocation(code), nil
+		}
+	}
+	location.country = countries[0]
+	location.timezone = primaryZone(countries[0])
+	return location, nil
+}
+
+// isCountryCode reports whether 'code' is an alpha-2 or alpha-3 country code.
+func isCountryCode(code string) bool {
+	code = strings.ToUpper(code)
+	_, alpha2 := tzdata.Alpha2ToCountry[code]
+	_, alpha3 := tzdata.Alpha3ToCountry[code]
+	return alpha2 || alpha3
+}
+
+// homeLocation returns the reference location used to compute time differences.
+// An empty 'home' gives the system's local zone.
+func (r *Resolver) homeLocation(home string) (*time.Location, error) {
+	if home == "" {
+		return time.Local, nil
+	}
+	place, err := r.resolvePlace(home)
+	if err != nil {
+		return nil, fmt.Errorf("Home zone: %v", err)
+	}
+	return loadLocation(place.timezone)
+}
+
+// offsetDifference returns how far the UTC offset of 't' is ahead of the UTC offset of 'ref'.
+func offsetDifference(ref, t time.Time) time.Duration {
+	_, refOffset := ref.Zone()
+	_, offset := t.Zone()
+	return time.Duration(offset-refOffset) * time.Second
+}
+
+// dayDifference returns the number of calendar days between the local date of 'ref'
+// and the local date of 't' (e.g. 1 when it's already tomorrow at 't').
+func dayDifference(ref, t time.Time) int {
+	refDate := time.Date(ref.Year(), ref.Month(), ref.Day(), 0, 0, 0, 0, time.UTC)
+	date := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return int(date.Sub(refDate).Hours() / 24)
+}
+
+// formatOffsetDifference formats an offset difference like "+10h45m", "-3h" or "±0h".
+func formatOffsetDifference(d time.Duration) string {
+	if d == 0 {
+		return "±0h"
+	}
+	sign := "+"
+	if d < 0 {
+		sign = "-"
+		d = -d
+	}
+	hours := int(d.Hours())
+	minutes := int(d.Minutes()) % 60
+	if minutes == 0 {
+		return fmt.Sprintf("%s%dh", sign, hours)
+	}
+	return fmt.Sprintf("%s%dh%02dm", sign, hours, minutes)
+}
+
+// formatUTCOffset formats a UTC offset in seconds like "UTC+05:45" or "UTC-03:00".
+func formatUTCOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("UTC%s%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// describeDifference describes the time at 't' relative to 'ref', e.g. "+10h45m, tomorrow".
+func describeDifference(ref, t time.Time) string {
+	description := formatOffsetDifference(offsetDifference(ref, t))
+	switch days := dayDifference(ref, t); {
+	case days == 1:
+		description += ", tomorrow"
+	case days == -1:
+		description += ", yesterday"
+	case days != 0:
+		description += fmt.Sprintf(", %+d days", days)
+	}
+	return description
+}
+
+// describeDay describes the day at 't' relative to the day at 'ref' with its weekday,
+// e.g. "today, Sun" or "tomorrow, Mon". A day other than the one at 'ref' is marked with
+// a leading "» " so that it stands out in a column, and with "(date line)" when the UTC
+// offsets are more than 12 hours apart, i.e. the places are closer across the date line.
+func describeDay(ref, t time.Time) string {
+	weekday := t.Format("Mon")
+	days := dayDifference(ref, t)
+	var description string
+	switch {
+	case days == 0:
+		return "today, " + weekday
+	case days == 1:
+		description = "» tomorrow, " + weekday
+	case days == -1:
+		description = "» yesterday, " + weekday
+	default:
+		description = fmt.Sprintf("» %+d days, %s", days, weekday)
+	}
+	if d := offsetDifference(ref, t); d > 12*time.Hour || d < -12*time.Hour {
+		description += " (date line)"
+	}
+	return description
+}
+
+// differenceFromHome describes the current time in the timezone 'tz' relative to the 'home' location.
+func differenceFromHome(tz string, home *time.Location) (string, error) {
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return "", err
+	}
+	now := time.Now()
+	return describeDifference(now.In(home), now.In(loc)), nil
+}
+
+// nextOffsetChange returns the next instant after 'from' at which the UTC offset of 'loc' changes.
+// It returns false if the offset of 'loc' never changes again.
+func nextOffsetChange(loc *time.Location, from time.Time) (time.Time, bool) {
+	t := from.In(loc)
+	_, offset := t.Zone()
+	// zone boundaries may only change the abbreviation, so keep walking until the offset changes
+	for i := 0; i < 8; i++ {
+		_, end := t.ZoneBounds()
+		if end.IsZero() {
+			return time.Time{}, false
+		}
+		if _, nextOffset := end.Zone(); nextOffset != offset {
+			return end, true
+		}
+		t = end
+	}
+	return time.Time{}, false
+}
+
+// ShowDifference prints the current time difference between two places and how
+// the difference changes at the next DST transition of either place.
+// Both 'placeA' and 'placeB' can be a city, a country or a timezone.
+func ShowDifference(r *Resolver, placeA, placeB string) {
+	locationA, err := r.resolvePlace(placeA)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	locationB, err := r.resolvePlace(placeB)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	locA, err := loadLocation(locationA.timezone)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	locB, err := loadLocation(locationB.timezone)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	renderDifferenceTable(placeA, placeB, differenceRows(locA, locB, time.Now()))
+}
+
+// differenceRows returns the table rows of the `diff` command for the instant 'now':
+// one row for now and one for the next offset change of either location, if any.
+func differenceRows(locA, locB *time.Location, now time.Time) [][]string {
+	customFormat := config.shortTimeLayout()
+	rows := [][]string{{
+		"Now",
+		now.In(locA).Format(customFormat),
+		now.In(locB).Format(customFormat),
+		describeDifference(now.In(locA), now.In(locB)),
+	}}
+
+	changeA, okA := nextOffsetChange(locA, now)
+	changeB, okB := nextOffsetChange(locB, now)
+	var change time.Time
+	var changedZone string
+	switch {
+	case okA && (!okB || !changeB.Before(changeA)):
+		change, changedZone = changeA, locA.String()
+	case okB:
+		change, changedZone = changeB, locB.String()
+	default:
+		return rows
+	}
+	rows = append(rows, []string{
+		fmt.Sprintf("From %s (%s)", change.UTC().Format("02 Jan 2006"), changedZone),
+		change.In(locA).Format(customFormat),
+		change.In(locB).Format(customFormat),
+		describeDifference(change.In(locA), change.In(locB)),
+	})
+	return rows
+}
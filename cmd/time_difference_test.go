@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatOffsetDifference(t *testing.T) {
+	tests := []struct {
+		given time.Duration
+		want  string
+	}{
+		{given: 0, want: "±0h"},
+		{given: 10*time.Hour + 45*time.Minute, want: "+10h45m"},
+		{given: -3 * time.Hour, want: "-3h"},
+		{given: -(5*time.Hour + 30*time.Minute), want: "-5h30m"},
+	}
+	for _, test := range tests {
+		got := formatOffsetDifference(test.given)
+		if got != test.want {
+			t.Fatalf("`formatOffsetDifference(%v)=%v`, want %v", test.given, got, test.want)
+		}
+	}
+}
+
+func TestDescribeDifference(t *testing.T) {
+	kathmandu, _ := loadLocation("Asia/Kathmandu")
+	newYork, _ := loadLocation("America/New_York")
+	tests := []struct {
+		name    string
+		instant time.Time
+		ref     *time.Location
+		loc     *time.Location
+		want    string
+	}{
+		{"same day", time.Date(2026, 7, 1, 12, 0, 0, 0, time.UTC), newYork, kathmandu, "+9h45m"},
+		{"tomorrow", time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC), newYork, kathmandu, "+9h45m, tomorrow"},
+		{"yesterday", time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC), kathmandu, newYork, "-9h45m, yesterday"},
+		{"same zone", time.Date(2026, 7, 1, 20, 0, 0, 0, time.UTC), kathmandu, kathmandu, "±0h"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := describeDifference(test.instant.In(test.ref), test.instant.In(test.loc))
+			if got != test.want {
+				t.Fatalf("`describeDifference(%v, %v)=%v`, want %v", test.ref, test.loc, got, test.want)
+			}
+		})
+	}
+}
+
+func TestNextOffsetChange(t *testing.T) {
+	newYork, _ := loadLocation("America/New_York")
+	kathmandu, _ := loadLocation("Asia/Kathmandu")
+
+	got, ok := nextOffsetChange(newYork, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC))
+	want := time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC)
+	if !ok || !got.Equal(want) {
+		t.Fatalf("`nextOffsetChange(America/New_York)=%v, %v`, want %v, true", got, ok, want)
+	}
+	if got, ok := nextOffsetChange(kathmandu, time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)); ok {
+		t.Fatalf("`nextOffsetChange(Asia/Kathmandu)=%v, %v`, want no change", got, ok)
+	}
+}
+
+func TestDifferenceRows(t *testing.T) {
+	kathmandu, _ := loadLocation("Asia/Kathmandu")
+	newYork, _ := loadLocation("America/New_York")
+	rows := differenceRows(kathmandu, newYork, time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC))
+	if len(rows) != 2 {
+		t.Fatalf("`differenceRows` returned %d rows, want 2", len(rows))
+	}
+	if rows[0][3] != "-9h45m" || rows[1][3] != "-10h45m" {
+		t.Fatalf("`differenceRows` differences = %v, %v, want -9h45m, -10h45m", rows[0][3], rows[1][3])
+	}
+}
+
+func TestResolvePlace(t *testing.T) {
+	tests := []struct {
+		given string
+		want  string
+	}{
+		{given: "Asia/Kathmandu", want: "Asia/Kathmandu"},
+		{given: "pst", want: "America/Los_Angeles"},
+		{given: "Berlin", want: "Europe/Berlin"},
+		{given: "NP", want: "Asia/Kathmandu"},
+		{given: "Nepal", want: "Asia/Kathmandu"},
+	}
+	for _, test := range tests {
+		got, err := DefaultResolver().resolvePlace(test.given)
+		if err != nil {
+			t.Fatalf("`resolvePlace(%v)` returned error %v", test.given, err)
+		}
+		if got.timezone != test.want {
+			t.Fatalf("`resolvePlace(%v)=%v`, want %v", test.given, got.timezone, test.want)
+		}
+	}
+	if _, err := DefaultResolver().resolvePlace("Xyzzy"); err == nil {
+		t.Fatalf("`resolvePlace(Xyzzy)` returned no error")
+	}
+}
+
+func TestDescribeDay(t *testing.T) {
+	losAngeles, _ := loadLocation("America/Los_Angeles")
+	auckland, _ := loadLocation("Pacific/Auckland")
+	london, _ := loadLocation("Europe/London")
+	honolulu, _ := loadLocation("Pacific/Honolulu")
+	kiritimati, _ := loadLocation("Pacific/Kiritimati")
+	sunday := time.Date(2026, 10, 18, 17, 0, 0, 0, losAngeles)
+	tests := []struct {
+		name string
+		ref  time.Time
+		loc  *time.Location
+		want string
+	}{
+		{"same day", sunday, honolulu, "today, Sun"},
+		{"past midnight", sunday, london, "» tomorrow, Mon"},
+		{"date line", sunday, auckland, "» tomorrow, Mon (date line)"},
+		{"same date across the date line", sunday.Add(-14 * time.Hour), auckland, "today, Sun"},
+		{"date line westwards", sunday.In(kiritimati), honolulu, "» yesterday, Sun (date line)"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := describeDay(test.ref, test.ref.In(test.loc)); got != test.want {
+				t.Fatalf("`describeDay(%v, %v)` = %q, want %q", test.ref, test.loc, got, test.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"github.com/kritibb/ktz/tzdata"
+	"github.com/kritibb/ktz/tzif"
+	"os"
+	"sort"
 	"strings"
 	"time"
 )
@@ -10,14 +14,35 @@ import (
 type zoneInfo struct {
 	formattedTime string
 	timezoneName  string
-	abbreviation  string
+	abbreviation  string    // The abbreviation given by the user, if any
+	current       string    // The abbreviation in effect there now, e.g. PDT for PST in summer
+	difference    string    // The difference to the home zone (e.g. +10h45m, tomorrow)
+	status        string    // Whether it's working hours, evening, night or weekend there
+	holiday       string    // The public holidays there today, if any
+	sun           *solarDay // The sun times today with LookupOptions.Daylight, if the coordinates are known
 }
 
 type locationInfo struct {
-	country       string // The country name or code (e.g., USA, IN)
-	city          string // The city name (e.g., New York, London)
-	timezone      string // The full timezone name (e.g., America/New_York)
-	formattedTime string // The time formatted according to the timezone
+	country       string    // The country name or code (e.g., USA, IN)
+	city          string    // The city name (e.g., New York, London)
+	subdivision   string    // The state, province or oblast name (e.g., California), if any
+	timezone      string    // The full timezone name (e.g., America/New_York)
+	formattedTime string    // The time formatted according to the timezone
+	difference    string    // The difference to the home zone (e.g. +10h45m, tomorrow)
+	place         string    // The place as given by the user when it is part of an alias
+	alias         string    // The alias chosen instead of a single location, if any
+	status        string    // Whether it's working hours, evening, night or weekend there
+	holiday       string    // The public holidays there today, if any
+	sun           *solarDay // The sun times today with LookupOptions.Daylight, if the coordinates are known
+	daylight      string    // Whether it's day or night there, with the next sunrise or sunset
+	day           string    // The day relative to the reference zone, e.g. "» tomorrow, Mon", in tables of several places
+}
+
+// LookupOptions holds the options of the `lookup` command.
+type LookupOptions struct {
+	Home       string // The place the time difference is computed against; empty means the system zone
+	Daylight   bool   // Show the sunrise, sunset, solar noon and day length
+	StrictAbbr bool   // Take an abbreviation like PST as its fixed UTC offset, even when it isn't in effect
 }
 
 // ResolveTimeZone prints the current time in the specified location.
@@ -25,121 +50,302 @@ type locationInfo struct {
 // The 'zone' parameter should be a timezone like 'Asia/Kathmandu' or 'PST'
 // The 'country' parameter should be a prefix/ complete country or a country code.
 // Time is displayed based on either location or zone
+// The 'opts' parameter holds further options like the home zone.
 // If the location is invalid, an error message is printed, else
 // timezone is displayed based on it.
-func ResolveTimezone(city, country, zone string) {
+func ResolveTimezone(r *Resolver, city, country, zone string, opts LookupOptions) {
+	if opts.Home == "" {
+		opts.Home = r.home
+	}
+	home, err := r.homeLocation(opts.Home)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
 	if zone != "" {
-		zoneData, err := getDataFromZone(zone)
+		now := time.Now()
+		zoneData, err := r.getDataFromZone(zone, opts.StrictAbbr)
 		if err != nil {
 			fmt.Printf("\nError: %v\n", err)
 			return
 		}
+		if zoneData.abbreviation != "" && !opts.StrictAbbr {
+			abbr, _ := r.abbreviationMeaning(zoneData.abbreviation)
+			if warning := abbreviationWarning(zoneData.abbreviation, abbr, now); warning != "" {
+				fmt.Fprintf(os.Stderr, "\nWarning: %v\n", warning)
+			}
+		}
+		if zoneData.difference, err = differenceFromHome(zoneData.timezoneName, home); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+		zoneLocation := locationInfo{timezone: zoneData.timezoneName}
+		if zoneData.status, err = r.locationStatus(zoneLocation, now); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+		if zoneData.holiday, err = r.holidayAt(zoneLocation, now); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+		if opts.Daylight {
+			if zoneData.sun, err = locationSun(zoneLocation, now); err != nil {
+				fmt.Printf("\nError: %v\n", err)
+				return
+			}
+		}
 		renderZoneInfoTable(zoneData)
 		return
 	}
 	//Get potential location based on the provided city/country string
-	locationList, err := getMatchingLocation(city, country)
+	locationList, err := r.getMatchingLocation(city, country)
 	if err != nil {
 		fmt.Println("\nError:", err)
 		return
 	}
-	currentLocationData, errLocation := getDataFromLocation(locationList)
-	if errLocation != nil {
+	currentLocationData, errLocation := r.getDataFromLocation(locationList)
+	if errors.Is(errLocation, errNoChoice) {
+		return
+	} else if errLocation != nil {
+		fmt.Printf("\nError: %v\n", errLocation)
+		return
+	}
+	r.showLocation(currentLocationData, home, opts.Daylight)
+}
+
+// showLocation prints the table of a location chosen by a lookup, with its difference to
+// 'home', its status and holidays and, if 'daylight' is set, its sun times; for an alias,
+// the table of its places.
+func (r *Resolver) showLocation(location locationInfo, home *time.Location, daylight bool) {
+	var err error
+	now := time.Now()
+	if location.alias != "" {
+		r.showAlias(location.alias, home, daylight, now)
+		return
+	}
+	if location.difference, err = differenceFromHome(location.timezone, home); err != nil {
 		fmt.Printf("\nError: %v\n", err)
 		return
 	}
-	renderDateTimeTableFromLocation(currentLocationData)
-	return
+	if location.status, err = r.locationStatus(location, now); err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	if location.holiday, err = r.holidayAt(location, now); err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	if daylight {
+		if location.sun, err = locationSun(location, now); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			return
+		}
+	}
+	renderDateTimeTableFromLocation(location)
 }
 
-// getDataFromLocation gives locationInfo based on given locationList (city/country)
+// errNoChoice is returned when the user quits the picker without choosing a location.
+var errNoChoice = errors.New("No location chosen.")
+
+// getDataFromLocation gives locationInfo based on given locationList (city/country),
+// letting the user pick one if several locations match. Without a terminal to pick one,
+// the closest match and the primary zone of a country are used.
 //
 // Parameters:
 //   - locationList: A country or city list
 //
 // Returns:
 //   - locationInfo:
-//   - error: any error message if zone does not exist
+//   - error: errNoChoice if the user quits the picker, or any error message if zone does not exist
 
-func getDataFromLocation(locationList []string) (locationInfo, error) {
+func (r *Resolver) getDataFromLocation(locationList []string) (locationInfo, error) {
+	var location locationInfo
 	//if locationList consists only one location, set timezone, city and country based on that
 	if len(locationList) == 1 {
-		location := locationList[0]
-		if val, ok := tzdata.CityToIanaTimezone[location]; ok {
-			locationData.timezone = val["tz"]
-			locationData.city = location
-			locationData.country = val["country"]
-		} else if val, ok := tzdata.CountryToIanaTimezone[location]; ok {
-			if len(val) > 1 {
-				locationData.country = location
-				listViewTz(val)
+		name := locationList[0]
+		if alias, _, ok := r.findAlias(name); ok && alias == name {
+			return locationInfo{alias: alias}, nil
+		} else if val, ok := tzdata.CityToIanaTimezone[name]; ok {
+			location = locationInfo{city: name, country: canonicalCountry(val["country"]), timezone: val["tz"]}
+		} else if val, ok := tzdata.CountryToIanaTimezone[name]; ok {
+			if len(val) > 1 && r.interactive() {
+				// the user may go back in the explorer and choose a zone of another country
+				location = listViewCountryTz(name)
+			}
+			if location.country == "" {
+				location.country = name
 			}
-			locationData.timezone = val[0]
-			locationData.country = location
+			if location.timezone == "" {
+				location.timezone = primaryZone(name)
+			}
+		} else if code, ok := findSubdivision(name); ok {
+			location = subdivisionLocation(code)
 		}
+	} else if !r.interactive() {
+		// without a terminal to pick one of them, the closest match is used
+		return r.getDataFromLocation(locationList[:1])
 	} else {
-		listViewTz(locationList)
+		location = listViewTz(r, locationList)
+		if location.alias != "" {
+			return location, nil
+		}
+		if location.timezone == "" {
+			return location, errNoChoice
+		}
 	}
-	datetime, err := formatTime(locationData.timezone)
+	datetime, err := r.formatTime(location.timezone, time.Now())
 	if err != nil {
-		return locationData, err
+		return location, err
 	}
-	locationData.formattedTime = datetime
-	return locationData, err
+	location.formattedTime = datetime
+	return location, err
 }
 
 // getDataFromZone gives zoneInfo based on given zone name
 //
 // Parameters:
 //   - zone: The timezone in string format;
-//          could either be abbreviation like pst or full name like "Asia/Kathmandu"
+//          could either be abbreviation like pst, full name like "Asia/Kathmandu"
+//          or a POSIX TZ string like "EST5EDT,M3.2.0,M11.1.0"
+//   - strict: Whether an abbreviation stands for its fixed UTC offset rather than its timezone
 //
 // Returns:
 //   - zoneInfo:
 //   - error: any error message if zone does not exist
 
-func getDataFromZone(zone string) (zoneInfo, error) {
+func (r *Resolver) getDataFromZone(zone string, strict bool) (zoneInfo, error) {
 	var zoneData zoneInfo
 	var err error
-	if len(zone) < 6 {
+	if len(zone) < 6 && !isPOSIXTZ(zone) {
 		zoneData.abbreviation = zone
-		if val, ok := tzdata.AbbToIanaTimezone[strings.ToUpper(zone)]; !ok {
+		if abbr, ok := r.abbreviationMeaning(zone); !ok {
 			err = fmt.Errorf("Zone abbreviation '%v' not found.\n\n", zone)
 			return zoneData, err
+		} else if strict && !abbr.Generic {
+			zoneData.timezoneName = fixedZone(strings.ToUpper(zone), abbr.Offset)
 		} else {
-			zoneData.timezoneName = val
+			zoneData.timezoneName = abbr.Zone
 		}
 	} else {
 		zoneData.timezoneName = zone
 	}
-	datetime, err := formatTime(zoneData.timezoneName)
+	datetime, err := r.formatTime(zoneData.timezoneName, time.Now())
 	if err != nil {
 		return zoneData, err
 	}
 	zoneData.formattedTime = datetime
+	loc, err := loadLocation(zoneData.timezoneName)
+	if err != nil {
+		return zoneData, err
+	}
+	zoneData.current, _ = time.Now().In(loc).Zone()
 	return zoneData, err
 }
 
+// lookupAbbreviation returns the timezone of a zone abbreviation like 'PST'.
+// Abbreviations configured by the user take precedence over the built-in ones.
+func (r *Resolver) lookupAbbreviation(abbreviation string) (string, bool) {
+	abbr, ok := r.abbreviationMeaning(abbreviation)
+	return abbr.Zone, ok
+}
+
+// abbreviationMeaning returns the meaning of a zone abbreviation like 'PST', preferring
+// the abbreviations configured by the user to the built-in ones.
+func (r *Resolver) abbreviationMeaning(abbreviation string) (tzdata.Abbreviation, bool) {
+	abbreviation = strings.ToUpper(abbreviation)
+	if tz, ok := r.abbreviations[abbreviation]; ok {
+		return configuredAbbreviation(abbreviation, tz, time.Now()), true
+	}
+	abbr, ok := tzdata.AbbToIanaTimezone[abbreviation]
+	return abbr, ok
+}
+
+// configuredAbbreviation returns the meaning of an abbreviation the user configured to stand
+// for the timezone 'tz': the offset the zone has with the abbreviation in the year of 'now',
+// or a generic abbreviation if the zone doesn't use it then.
+func configuredAbbreviation(abbreviation, tz string, now time.Time) tzdata.Abbreviation {
+	loc, err := loadLocation(tz)
+	if err != nil {
+		return tzdata.Abbreviation{Zone: tz, Generic: true}
+	}
+	for _, month := range []time.Month{time.January, time.July} {
+		t := time.Date(now.Year(), month, 1, 12, 0, 0, 0, loc)
+		if name, offset := t.Zone(); strings.EqualFold(name, abbreviation) {
+			return tzdata.Abbreviation{Zone: tz, Offset: offset, DST: t.IsDST()}
+		}
+	}
+	return tzdata.Abbreviation{Zone: tz, Generic: true}
+}
+
+// abbreviationWarning returns a warning if the abbreviation 'name' isn't in effect in its
+// timezone at 'now', e.g. for PST in July, when Los Angeles is on PDT. Generic abbreviations
+// like ET are always in effect.
+func abbreviationWarning(name string, abbr tzdata.Abbreviation, now time.Time) string {
+	if abbr.Generic {
+		return ""
+	}
+	loc, err := loadLocation(abbr.Zone)
+	if err != nil {
+		return ""
+	}
+	current, offset := now.In(loc).Zone()
+	if offset == abbr.Offset {
+		return ""
+	}
+	kind := "standard time"
+	if abbr.DST {
+		kind = "daylight saving time"
+	}
+	return fmt.Sprintf("%v is %v (%v), but %v is on %v (%v) now. Use -strict-abbr for a fixed %v.",
+		strings.ToUpper(name), kind, formatUTCOffset(abbr.Offset), loc, current, formatUTCOffset(offset), formatUTCOffset(abbr.Offset))
+}
+
+// fixedZone returns a POSIX TZ string for the fixed UTC offset 'offset' in seconds, named 'name'.
+func fixedZone(name string, offset int) string {
+	return tzif.POSIXTZ{StdName: name, StdOffset: int32(offset)}.String()
+}
+
+// representativeCity returns a city using the timezone 'tz': a known city if any,
+// otherwise the city in the timezone name (e.g. 'Srednekolymsk' for 'Asia/Srednekolymsk').
+func representativeCity(tz string) string {
+	var cities []string
+	for city, val := range tzdata.CityToIanaTimezone {
+		if val["tz"] == tz {
+			cities = append(cities, city)
+		}
+	}
+	if len(cities) > 0 {
+		sort.Strings(cities)
+		return cities[0]
+	}
+	return strings.ReplaceAll(tz[strings.LastIndex(tz, "/")+1:], "_", " ")
+}
+
+// zoneRegion returns the region of a timezone name, e.g. 'Asia' for 'Asia/Kathmandu'.
+func zoneRegion(tz string) string {
+	if i := strings.Index(tz, "/"); i >= 0 {
+		return tz[:i]
+	}
+	return tz
+}
+
 // formatTime displays time for a given timeZone in a specified fromat.
 //
 // Parameters:
 //   - tz: The timezone in string format.
+//   - now: The instant to display.
 //
 // Returns:
 //   - string: time of a particular tz in a certain format
-//   - error: any error message if time.LoadLocation does not find the given tz
-func formatTime(tz string) (string, error) {
-	loc, err := time.LoadLocation(tz)
+//   - error: any error message if loadLocation does not find the given tz
+func (r *Resolver) formatTime(tz string, now time.Time) (string, error) {
+	loc, err := loadLocation(tz)
 	if err != nil {
 		return "", err
 	}
-	// Get current time in UTC
-	utcTime := time.Now().UTC()
-
-	// Convert UTC time to local time of the specified location
-	localTime := utcTime.In(loc)
+	// Convert the instant to local time of the specified location
+	localTime := now.In(loc)
 
-	// Print the local time
-	const customFormat = "Mon, 02 Jan 2006 03:04:05 PM"
-	return localTime.Format(customFormat), nil
+	// Print the local time in the configured format
+	return localTime.Format(r.timeLayout), nil
 }
@@ -1,6 +1,12 @@
 package cmd
 
-import "testing"
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
 
 func TestFormatTime(t *testing.T) {
 	tests := []struct {
@@ -14,10 +20,87 @@ func TestFormatTime(t *testing.T) {
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			_, gotErr := formatTime(test.givenTz)
+			_, gotErr := DefaultResolver().formatTime(test.givenTz, time.Now())
 			if (gotErr != nil) != test.wantErr {
 				t.Errorf("Expected error: %v, but got: %v", test.wantErr, gotErr)
 			}
 		})
 	}
 }
+
+func TestAbbreviationWarning(t *testing.T) {
+	july := time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)
+	january := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		abbreviation string
+		now          time.Time
+		wantWarning  bool
+	}{
+		{"PST", july, true},
+		{"PST", january, false},
+		{"pdt", july, false},
+		{"PDT", january, true},
+		{"ET", january, false},
+		{"ET", july, false},
+		{"IST", july, false},
+		{"UTC", july, false},
+	}
+	for _, tt := range tests {
+		abbr, ok := DefaultResolver().abbreviationMeaning(tt.abbreviation)
+		if !ok {
+			t.Fatalf("`abbreviationMeaning(%v)` not found", tt.abbreviation)
+		}
+		if got := abbreviationWarning(tt.abbreviation, abbr, tt.now); (got != "") != tt.wantWarning {
+			t.Fatalf("`abbreviationWarning(%v, %v)` = %q, want a warning: %v", tt.abbreviation, tt.now, got, tt.wantWarning)
+		}
+	}
+	abbr, _ := DefaultResolver().abbreviationMeaning("PST")
+	if got := abbreviationWarning("PST", abbr, july); !strings.Contains(got, "PDT (UTC-07:00)") {
+		t.Fatalf("`abbreviationWarning(PST)` in July = %q, want the current PDT", got)
+	}
+}
+
+func TestBuiltinAbbreviations(t *testing.T) {
+	// abbreviations still used by their zone must have the offset and DST flag the zone has with them
+	for name, abbr := range tzdata.AbbToIanaTimezone {
+		loc, err := loadLocation(abbr.Zone)
+		if err != nil {
+			t.Fatalf("abbreviation %v: %v", name, err)
+		}
+		for _, month := range []time.Month{time.January, time.July} {
+			at := time.Date(2026, month, 1, 12, 0, 0, 0, loc)
+			if current, offset := at.Zone(); current == name && (offset != abbr.Offset || at.IsDST() != abbr.DST) {
+				t.Fatalf("abbreviation %v = %+v, but %v has %v, DST %v", name, abbr, loc, formatUTCOffset(offset), at.IsDST())
+			}
+		}
+	}
+}
+
+func TestConfiguredAbbreviation(t *testing.T) {
+	now := time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)
+	if got := configuredAbbreviation("IST", "Asia/Jerusalem", now); got.Offset != 2*3600 || got.DST || got.Generic {
+		t.Fatalf("`configuredAbbreviation(IST, Asia/Jerusalem)` = %+v, want standard UTC+02:00", got)
+	}
+	if got := configuredAbbreviation("CST", "Asia/Shanghai", now); got.Offset != 8*3600 || got.Generic {
+		t.Fatalf("`configuredAbbreviation(CST, Asia/Shanghai)` = %+v, want UTC+08:00", got)
+	}
+	if got := configuredAbbreviation("XYZ", "Europe/Paris", now); !got.Generic {
+		t.Fatalf("`configuredAbbreviation(XYZ, Europe/Paris)` = %+v, want generic", got)
+	}
+}
+
+func TestStrictAbbreviation(t *testing.T) {
+	zoneData, err := DefaultResolver().getDataFromZone("PST", true)
+	if err != nil {
+		t.Fatalf("`getDataFromZone(PST, strict)` error: %v", err)
+	}
+	if zoneData.timezoneName != "PST8" || zoneData.current != "PST" {
+		t.Fatalf("`getDataFromZone(PST, strict)` = %+v, want the fixed zone PST8", zoneData)
+	}
+	if zoneData, _ = DefaultResolver().getDataFromZone("ET", true); zoneData.timezoneName != "America/New_York" {
+		t.Fatalf("`getDataFromZone(ET, strict)` = %+v, want America/New_York", zoneData)
+	}
+	if got := fixedZone("NPT", 5*3600+45*60); got != "NPT-5:45" {
+		t.Fatalf("`fixedZone(NPT, +05:45)` = %q", got)
+	}
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// tomlEntry is a single `key = value` pair of a TOML document.
+// The value is either a string, an int64, a bool or a []string.
+type tomlEntry struct {
+	path    []string // The table names followed by the key (e.g. [abbreviations IST])
+	value   any
+	line    int      // The line number of the entry, starting at 1
+	endLine int      // The last line of the entry, after 'line' for arrays over several lines
+	table   []string // The table the entry is written in, a prefix of 'path'
+}
+
+// parseTOML parses the subset of TOML used by the configuration file:
+// comments, tables (`[section]`, `[section."sub key"]`), bare or quoted keys, and
+// string, integer, boolean and string-array values.
+//
+// Parameters:
+//   - doc: The content of the TOML document.
+//
+// Returns:
+//   - []tomlEntry: The entries in the order they appear in the document.
+//   - error: A syntax error pointing to the offending line.
+func parseTOML(doc string) ([]tomlEntry, error) {
+	var entries []tomlEntry
+	var table []string
+	lines := strings.Split(doc, "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNo := i + 1
+		line := strings.TrimSpace(stripComment(lines[i]))
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") || strings.HasPrefix(line, "[[") {
+				return nil, fmt.Errorf("line %d: invalid table header %q", lineNo, line)
+			}
+			path, err := splitKeyPath(strings.TrimSpace(line[1 : len(line)-1]))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %v", lineNo, err)
+			}
+			table = path
+			continue
+		}
+		eq := indexOutsideQuotes(line, '=')
+		if eq < 0 {
+			return nil, fmt.Errorf("line %d: expected `key = value`", lineNo)
+		}
+		keyPath, err := splitKeyPath(strings.TrimSpace(line[:eq]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", lineNo, err)
+		}
+		rawValue := strings.TrimSpace(line[eq+1:])
+		// arrays may span several lines
+		for strings.HasPrefix(rawValue, "[") && !strings.HasSuffix(rawValue, "]") && i+1 < len(lines) {
+			i++
+			rawValue += " " + strings.TrimSpace(stripComment(lines[i]))
+		}
+		value, err := parseTOMLValue(rawValue)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v: %v", lineNo, strings.Join(keyPath, "."), err)
+		}
+		path := append(append([]string{}, table...), keyPath...)
+		entries = append(entries, tomlEntry{path: path, value: value, line: lineNo, endLine: i + 1, table: table})
+	}
+	return entries, nil
+}
+
+// parseTOMLValue parses a single string, integer, boolean or string-array value.
+func parseTOMLValue(raw string) (any, error) {
+	switch {
+	case raw == "":
+		return nil, fmt.Errorf("missing value")
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	case strings.HasPrefix(raw, "\"") || strings.HasPrefix(raw, "'"):
+		s, rest, err := parseTOMLString(raw)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(rest) != "" {
+			return nil, fmt.Errorf("unexpected %q after string", rest)
+		}
+		return s, nil
+	case strings.HasPrefix(raw, "["):
+		if !strings.HasSuffix(raw, "]") {
+			return nil, fmt.Errorf("unterminated array")
+		}
+		list := []string{}
+		rest := strings.TrimSpace(raw[1 : len(raw)-1])
+		for rest != "" {
+			s, after, err := parseTOMLString(rest)
+			if err != nil {
+				return nil, fmt.Errorf("arrays may only contain strings")
+			}
+			list = append(list, s)
+			rest = strings.TrimSpace(after)
+			if rest != "" {
+				if rest[0] != ',' {
+					return nil, fmt.Errorf("expected ',' in array")
+				}
+				rest = strings.TrimSpace(rest[1:])
+			}
+		}
+		return list, nil
+	default:
+		n, err := strconv.ParseInt(strings.ReplaceAll(raw, "_", ""), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q", raw)
+		}
+		return n, nil
+	}
+}
+
+// parseTOMLString parses a basic ("...") or literal ('...') string at the start of 's'
+// and returns it together with the remaining input.
+func parseTOMLString(s string) (string, string, error) {
+	if s == "" || (s[0] != '"' && s[0] != '\'') {
+		return "", s, fmt.Errorf("expected a string")
+	}
+	quote := s[0]
+	var sb strings.Builder
+	for i := 1; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch == quote:
+			return sb.String(), s[i+1:], nil
+		case ch == '\\' && quote == '"' && i+1 < len(s):
+			i++
+			switch s[i] {
+			case 'n':
+				sb.WriteByte('\n')
+			case 't':
+				sb.WriteByte('\t')
+			case '"', '\\':
+				sb.WriteByte(s[i])
+			default:
+				return "", s, fmt.Errorf("invalid escape sequence \\%c", s[i])
+			}
+		default:
+			sb.WriteByte(ch)
+		}
+	}
+	return "", s, fmt.Errorf("unterminated string")
+}
+
+// splitKeyPath splits a dotted key like `hours."Asia/Kathmandu".start` into its parts.
+func splitKeyPath(key string) ([]string, error) {
+	var path []string
+	rest := strings.TrimSpace(key)
+	for {
+		var part string
+		if strings.HasPrefix(rest, "\"") || strings.HasPrefix(rest, "'") {
+			s, after, err := parseTOMLString(rest)
+			if err != nil {
+				return nil, fmt.Errorf("invalid key %q", key)
+			}
+			part, rest = s, strings.TrimSpace(after)
+		} else {
+			end := strings.IndexByte(rest, '.')
+			if end < 0 {
+				end = len(rest)
+			}
+			part, rest = strings.TrimSpace(rest[:end]), rest[end:]
+			if !isBareKey(part) {
+				return nil, fmt.Errorf("invalid key %q", key)
+			}
+		}
+		path = append(path, part)
+		if rest == "" {
+			return path, nil
+		}
+		if rest[0] != '.' {
+			return nil, fmt.Errorf("invalid key %q", key)
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+}
+
+// joinKeyPath joins the parts of a key, quoting the parts that are not bare keys.
+func joinKeyPath(path []string) string {
+	parts := make([]string, len(path))
+	for i, part := range path {
+		parts[i] = formatTOMLKey(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// isBareKey reports whether 'key' can be written without quotes.
+func isBareKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, ch := range key {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '_' || ch == '-') {
+			return false
+		}
+	}
+	return true
+}
+
+// formatTOMLKey returns 'key' as is if it is a bare key, otherwise quoted.
+func formatTOMLKey(key string) string {
+	if isBareKey(key) {
+		return key
+	}
+	return strconv.Quote(key)
+}
+
+// formatTOMLValue formats a string, integer, boolean or string-array value.
+func formatTOMLValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// encodeTOML writes the entries as a TOML document. Top-level keys come first,
+// followed by one table per distinct prefix, both sorted by name.
+func encodeTOML(entries []tomlEntry) string {
+	tables := map[string][]tomlEntry{}
+	for _, entry := range entries {
+		table := joinKeyPath(entry.path[:len(entry.path)-1])
+		tables[table] = append(tables[table], entry)
+	}
+	names := make([]string, 0, len(tables))
+	for name := range tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		if name != "" {
+			if sb.Len() > 0 {
+				sb.WriteString("\n")
+			}
+			fmt.Fprintf(&sb, "[%s]\n", name)
+		}
+		tableEntries := tables[name]
+		sort.SliceStable(tableEntries, func(i, j int) bool {
+			return tableEntries[i].path[len(tableEntries[i].path)-1] < tableEntries[j].path[len(tableEntries[j].path)-1]
+		})
+		for _, entry := range tableEntries {
+			fmt.Fprintf(&sb, "%s = %s\n", formatTOMLKey(entry.path[len(entry.path)-1]), formatTOMLValue(entry.value))
+		}
+	}
+	return sb.String()
+}
+
+// setTOMLValue returns the document 'doc' with the value of the key at 'path' set to 'value',
+// or the key removed if 'value' is nil. Only the lines of that key change, so comments and
+// layout are kept: an existing key is rewritten in place, keeping its inline comment, and a
+// new key goes after the last key of its table, or into a new table at the end.
+func setTOMLValue(doc string, path []string, value any) (string, error) {
+	entries, err := parseTOML(doc)
+	if err != nil {
+		return "", err
+	}
+	lines := strings.Split(doc, "\n")
+	key := joinKeyPath(path)
+	table := path[:len(path)-1]
+	// the line to insert a new key after, 0 for the start of the document
+	after := -1
+	for _, entry := range entries {
+		if joinKeyPath(entry.path) == key {
+			var replacement []string
+			if value != nil {
+				last := lines[entry.endLine-1]
+				comment := strings.TrimPrefix(last, stripComment(last))
+				line := lines[entry.line-1]
+				rewritten := strings.TrimRight(line[:indexOutsideQuotes(line, '=')], " \t") + " = " + formatTOMLValue(value)
+				if comment != "" {
+					rewritten += " " + comment
+				}
+				replacement = []string{rewritten}
+			}
+			lines = append(lines[:entry.line-1], append(replacement, lines[entry.endLine:]...)...)
+			return strings.Join(lines, "\n"), nil
+		}
+		if len(entry.table) == len(table) && joinKeyPath(entry.table) == joinKeyPath(table) {
+			after = entry.endLine
+		}
+	}
+	if value == nil {
+		return doc, nil
+	}
+	entry := formatTOMLKey(path[len(path)-1]) + " = " + formatTOMLValue(value)
+	if after < 0 && len(table) == 0 {
+		// a first top-level key goes before the first header and the comments right above it
+		for i, line := range lines {
+			if strings.HasPrefix(strings.TrimSpace(line), "[") {
+				for i > 0 && strings.TrimSpace(lines[i-1]) != "" && strings.TrimSpace(stripComment(lines[i-1])) == "" {
+					i--
+				}
+				lines = append(lines[:i], append([]string{entry, ""}, lines[i:]...)...)
+				return strings.Join(lines, "\n"), nil
+			}
+		}
+	}
+	if after < 0 {
+		after = tomlTableLine(lines, table)
+	}
+	if after < 0 {
+		// a new table, or a first key of a document without tables, goes at the end
+		doc = strings.TrimRight(doc, "\n")
+		if doc != "" && len(table) > 0 {
+			doc += "\n\n"
+		} else if doc != "" {
+			doc += "\n"
+		}
+		return doc + encodeTOML([]tomlEntry{{path: path, value: value}}), nil
+	}
+	lines = append(lines[:after], append([]string{entry}, lines[after:]...)...)
+	return strings.Join(lines, "\n"), nil
+}
+
+// tomlTableLine returns the line number of the header of 'table' among the lines of a
+// document, or -1 if it has none.
+func tomlTableLine(lines []string, table []string) int {
+	for i, line := range lines {
+		line = strings.TrimSpace(stripComment(line))
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			if path, err := splitKeyPath(line[1 : len(line)-1]); err == nil && joinKeyPath(path) == joinKeyPath(table) {
+				return i + 1
+			}
+		}
+	}
+	return -1
+}
+
+// stripComment removes a trailing `# comment` that is not inside a string.
+func stripComment(line string) string {
+	if i := indexOutsideQuotes(line, '#'); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// indexOutsideQuotes returns the index of the first 'ch' in 's' that is not inside a string, or -1.
+func indexOutsideQuotes(s string, ch byte) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0 && s[i] == '\\' && quote == '"':
+			i++
+		case quote != 0 && s[i] == quote:
+			quote = 0
+		case quote == 0 && (s[i] == '"' || s[i] == '\''):
+			quote = s[i]
+		case quote == 0 && s[i] == ch:
+			return i
+		}
+	}
+	return -1
+}
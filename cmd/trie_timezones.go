@@ -1,26 +1,27 @@
 package cmd
 
 import (
+	"container/heap"
 	"fmt"
 	"sort"
 	"strings"
-	"sync"
 	"unicode"
 
 	"github.com/kritibb/ktz/tzdata"
 )
 
-// trieNode represents a node in the Trie data structure.
-// Each node contains a map of child nodes, a flag indicating if it is the end of a word,
-// and the original word stored at this node.
+// trieNode represents a node in the radix tree. The edge leading to the node holds 'label',
+// the characters shared by all words below it, so chains of single children are one node.
+// Children are sorted by the first byte of their label and found by binary search.
 type trieNode struct {
-	children     map[rune]*trieNode
+	label        string
+	children     []*trieNode
 	isWordEnd    bool
 	originalWord string
 }
 
-// trie represents the Trie data structure.
-// It contains a single root node from which all words are stored.
+// trie represents the radix tree, a path-compressed Trie.
+// It contains a single root node with an empty label from which all words are stored.
 type trie struct {
 	root *trieNode
 }
@@ -28,33 +29,74 @@ type trie struct {
 // newtrie creates a new Trie and initializes its root node.
 // It returns a pointer to the newly created Trie.
 func newtrie() *trie {
-	//same as trie:=new(trie); trie.root=new(trieNode{children:make(map[rune]*trieNode)});
-	//return trie
-	return &trie{root: &trieNode{children: make(map[rune]*trieNode)}}
+	return &trie{root: &trieNode{}}
+}
 
+// child returns the index of the child whose label starts with the byte 'b', or the index
+// a child starting with it would be inserted at, and whether it exists.
+func (n *trieNode) child(b byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool { return n.children[i].label[0] >= b })
+	return i, i < len(n.children) && n.children[i].label[0] == b
 }
 
 // insertWord adds a word to the Trie.
 // The 'word' parameter is the word to be added, and 'original' is the original
 // word (without removing spaces and non-alphanumeric characters) for reference.
-// It cleans the word and inserts each character into the Trie, creating new nodes as necessary.
+// It cleans the word and walks down the tree, splitting the label of a node where
+// the word leaves it. A word inserted twice keeps the last original.
 func (t *trie) insertWord(word, original string) {
 	word = cleanWord(word)
 	node := t.root
-	for _, ch := range word {
-		if node.children[ch] == nil {
-			node.children[ch] = &trieNode{children: make(map[rune]*trieNode)}
+	for word != "" {
+		i, ok := node.child(word[0])
+		if !ok {
+			leaf := &trieNode{label: word, isWordEnd: true, originalWord: original}
+			node.children = append(node.children, nil)
+			copy(node.children[i+1:], node.children[i:])
+			node.children[i] = leaf
+			return
 		}
-		node = node.children[ch]
-
+		child := node.children[i]
+		common := 1
+		for common < len(child.label) && common < len(word) && child.label[common] == word[common] {
+			common++
+		}
+		if common < len(child.label) {
+			split := &trieNode{label: child.label[:common], children: []*trieNode{child}}
+			child.label = child.label[common:]
+			node.children[i] = split
+			child = split
+		}
+		node = child
+		word = word[common:]
 	}
 	node.isWordEnd = true
 	node.originalWord = original
 }
 
+// findPrefix returns the highest node whose key starts with the cleaned prefix, and its key,
+// or nil if no word starts with it. The key is longer than the prefix if the prefix ends
+// within the label of the node.
+func (t *trie) findPrefix(prefix string) (*trieNode, string) {
+	node, key := t.root, ""
+	for len(key) < len(prefix) {
+		i, ok := node.child(prefix[len(key)])
+		if !ok {
+			return nil, ""
+		}
+		node = node.children[i]
+		rest := prefix[len(key):]
+		if !strings.HasPrefix(rest, node.label) && !strings.HasPrefix(node.label, rest) {
+			return nil, ""
+		}
+		key += node.label
+	}
+	return node, key
+}
+
 // searchWordWithPrefix searches the trie for words starting with the given prefix.
 // If the prefix exactly matches an original word, it returns that word.
-// Otherwise, it returns the two closest matches based on Levenshtein distance.
+// Otherwise, it returns the ten closest matches based on Levenshtein distance.
 //
 // Parameters:
 //   - prefix: The prefix to search for.
@@ -63,173 +105,175 @@ func (t *trie) insertWord(word, original string) {
 //   - bool: True if words with the given prefix were found, false otherwise.
 //   - []string: A slice of strings containing the closest matching words.
 func (t *trie) searchWordWithPrefix(prefix string) (bool, []string) {
-	prefix = cleanWord(prefix)
-	node := t.root
-	for _, ch := range prefix {
-		if node.children[ch] == nil {
-			return false, []string{}
-		}
-		node = node.children[ch]
-	}
-	if node.isWordEnd {
-		return true, []string{node.originalWord}
-	}
-	words := collectAllWords(node, prefix)
-	if len(words) == 0 {
-		return false, []string{}
-	}
-	return true, findClosestMatches(prefix, words, 10)
+	return searchWords(prefix, t)
 }
 
-// collectAllWords collects all words in the trie starting from the given node and prefix.
-// It a slice of strings containing all words.
-//
-// Parameters:
-//   - node: The starting node in the trie.
-//   - prefix: The prefix accumulated so far.
-//
-// Returns:
-//   - []string: A slice of strings containing all words found.
-func collectAllWords(node *trieNode, prefix string) []string {
-	var words []string
-	if node.isWordEnd {
-		words = append(words, node.originalWord)
+// wordIndex is an index of words searched by prefix, like a trie or the prebuilt search index.
+type wordIndex interface {
+	// matchPrefix returns the original word of the cleaned 'prefix' if it is a key, and up to
+	// 'k' words whose key starts with it (all of them if k <= 0), closest first.
+	matchPrefix(prefix string, k int) (exact string, isExact bool, matches []wordMatch)
+}
 
+func (t *trie) matchPrefix(prefix string, k int) (string, bool, []wordMatch) {
+	node, key := t.findPrefix(prefix)
+	if node == nil {
+		return "", false, nil
 	}
-	for char, childNode := range node.children {
-		childWords := collectAllWords(childNode, prefix+string(char))
-		words = append(words, childWords...)
+	closest := newClosestWords(k)
+	closest.collect(node, append(make([]byte, 0, 64), key...), len(prefix))
+	if node.isWordEnd && key == prefix {
+		return node.originalWord, true, closest.sorted()
 	}
-
-	return words
+	return "", false, closest.sorted()
 }
 
-// levenshteinDistance calculates the Levenshtein distance between two strings.
-// The Levenshtein distance is a measure of the difference between two sequences.
-// It is the minimum number of single-character edits (insertions, deletions or substitutions) required to change one word into the other.
-//
-// Parameters:
-//   - s1: The first string.
-//   - s2: The second string.
-//
-// Returns:
-//   - int: The Levenshtein distance between the two strings.
-func levenshteinDistance(s1, s2 string) int {
-	if len(s1) < len(s2) {
-		return levenshteinDistance(s2, s1)
-	}
-	if len(s2) == 0 {
-		return len(s1)
+// collect adds the words of the subtree of 'node', whose key is 'key', to the closest words.
+// The key is extended in place rather than concatenated, and subtrees whose keys are already
+// farther than the k-th closest word are skipped.
+func (c *closestWords) collect(node *trieNode, key []byte, prefixLength int) {
+	distance := len(key) - prefixLength
+	if c.full() && distance > c.matches[0].distance {
+		return
 	}
-	previousRow := make([]int, len(s2)+1)
-	for i := range previousRow {
-		previousRow[i] = i
+	if node.isWordEnd && c.accepts(distance, key) {
+		c.add(wordMatch{word: node.originalWord, key: string(key), distance: distance})
 	}
-	for i := range s1 {
-		currentRow := make([]int, len(s2)+1)
-		currentRow[0] = i + 1
-		for j := range s2 {
-			deletionCost := previousRow[j+1] + 1
-			insertionCost := currentRow[j] + 1
-			substitutionCost := previousRow[j]
-			if s1[i] != s2[j] {
-				substitutionCost++
-			}
-			currentRow[j+1] = min(insertionCost, deletionCost, substitutionCost)
-		}
-		previousRow = currentRow
+	for _, child := range node.children {
+		c.collect(child, append(key, child.label...), prefixLength)
 	}
-	return previousRow[len(s2)]
-
 }
 
-// cleanWord processes the input word to remove any non-letter and non-number characters,
-// converting all letters to lowercase. It returns the cleaned word.
-func cleanWord(word string) string {
-	var sb strings.Builder
-	for _, ch := range word {
-		if unicode.IsLetter(ch) || unicode.IsNumber(ch) {
-			sb.WriteRune(unicode.ToLower(ch))
+// searchWords searches several indexes for words starting with the given prefix like
+// searchWordWithPrefix: an exact match in the first index having one wins, otherwise
+// the ten closest matches among the words of all indexes are returned.
+func searchWords(prefix string, indexes ...wordIndex) (bool, []string) {
+	prefix = cleanWord(prefix)
+	closest := newClosestWords(10)
+	for _, index := range indexes {
+		exact, isExact, matches := index.matchPrefix(prefix, 10)
+		if isExact {
+			return true, []string{exact}
+		}
+		for _, match := range matches {
+			closest.add(match)
 		}
-
 	}
-	return sb.String()
+	if len(closest.matches) == 0 {
+		return false, []string{}
+	}
+	words := []string{}
+	seen := map[string]bool{}
+	for _, match := range closest.sorted() {
+		// aliases lead to the same word, like Holland and Netherlands
+		if !seen[match.word] {
+			seen[match.word] = true
+			words = append(words, match.word)
+		}
+	}
+	return true, words
 }
 
-// findClosestMatches finds the closest matches to the target word from the list of words
-// using the Levenshtein distance. It returns up to the specified number of closest matches.
-//
-// Parameters:
-//   - target: The target word to compare against.
-//   - words: The list of words to search through.
-//   - maxResults: The maximum number of closest matches to return.
-//
-// Returns:
-//   - []string: A slice of the closest matching words.
-func findClosestMatches(target string, words []string, maxResults int) []string {
-	type wordDistance struct {
-		word     string
-		distance int
-	}
+// wordMatch is a word whose key starts with a searched prefix.
+type wordMatch struct {
+	word     string // The original word
+	key      string // The cleaned word
+	distance int    // The Levenshtein distance from the prefix to the key: the length of the rest of the key
+}
 
-	var distances []wordDistance
-	for _, word := range words {
-		distances = append(distances, wordDistance{word: word, distance: levenshteinDistance(target, word)})
+// less orders matches by distance, then by key.
+func (m wordMatch) less(o wordMatch) bool {
+	if m.distance != o.distance {
+		return m.distance < o.distance
 	}
+	return m.key < o.key
+}
 
-	// Sort the words by distance
-	sort.Slice(distances, func(i, j int) bool {
-		return distances[i].distance < distances[j].distance
-	})
+// closestWords keeps the k closest of the words added to it in a max-heap, so the farthest
+// word is dropped first; the words are never all collected and sorted. A k <= 0 keeps all.
+type closestWords struct {
+	k       int
+	matches []wordMatch
+}
 
-	// Return the closest matches up to maxResults
-	var closestMatches []string
-	for i := 0; i < min(maxResults, int(len(distances))); i++ {
-		closestMatches = append(closestMatches, distances[i].word)
-	}
+func newClosestWords(k int) *closestWords {
+	return &closestWords{k: k}
+}
 
-	return closestMatches
+func (c *closestWords) Len() int           { return len(c.matches) }
+func (c *closestWords) Less(i, j int) bool { return c.matches[j].less(c.matches[i]) }
+func (c *closestWords) Swap(i, j int)      { c.matches[i], c.matches[j] = c.matches[j], c.matches[i] }
+func (c *closestWords) Push(x any)         { c.matches = append(c.matches, x.(wordMatch)) }
+func (c *closestWords) Pop() any {
+	last := c.matches[len(c.matches)-1]
+	c.matches = c.matches[:len(c.matches)-1]
+	return last
 }
 
-var (
-	new_trie = newtrie() //singleton instance of the Trie
-	once     sync.Once   //ensures the Trie is initialized only once (only works on online type setting
-	// where a program is once started and keeps running until manually exit)
-)
+// full reports whether k words are kept, so a word must be closer than the farthest to be kept.
+func (c *closestWords) full() bool {
+	return c.k > 0 && len(c.matches) >= c.k
+}
+
+// accepts reports whether a word with the given distance and key would be kept.
+func (c *closestWords) accepts(distance int, key []byte) bool {
+	if !c.full() {
+		return true
+	}
+	farthest := c.matches[0]
+	return distance < farthest.distance || distance == farthest.distance && string(key) < farthest.key
+}
 
-// initializetrie initializes the Trie with city names from CityToTimezone.
-func initializeCityTrie() {
-	for city := range tzdata.CityToIanaTimezone {
-		new_trie.insertWord(city, city)
+// add keeps the match if it is among the k closest.
+func (c *closestWords) add(match wordMatch) {
+	if !c.full() {
+		heap.Push(c, match)
+	} else if match.less(c.matches[0]) {
+		c.matches[0] = match
+		heap.Fix(c, 0)
 	}
 }
 
-// initializetrie initializes the Trie with city names from CityToTimezone.
-func initializeCountryTrie() {
-	for country := range tzdata.CountryToIanaTimezone {
-		new_trie.insertWord(country, country)
+// sorted returns the kept matches, closest first.
+func (c *closestWords) sorted() []wordMatch {
+	sorted := make([]wordMatch, len(c.matches))
+	copy(sorted, c.matches)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].less(sorted[j]) })
+	return sorted
+}
+
+// cleanWord processes the input word to remove any non-letter and non-number characters,
+// converting all letters to lowercase. It returns the cleaned word.
+func cleanWord(word string) string {
+	var sb strings.Builder
+	for _, ch := range word {
+		if unicode.IsLetter(ch) || unicode.IsNumber(ch) {
+			sb.WriteRune(unicode.ToLower(ch))
+		}
+
 	}
+	return sb.String()
 }
 
-// getMatchingLocation retrieves matching cities/countries based on a given prefix/city string by performing fuzzy search.
+// getMatchingLocation retrieves matching cities/countries/subdivisions based on a given prefix/city string by performing fuzzy search.
 //
-// It ensures the Trie is initialized before performing the search.
+// Cities are searched in the city index and the user's aliases, where an alias wins over a city
+// with the same name; countries in the country index and the subdivisions, where a country wins
+// over a subdivision with the same name (e.g. Georgia, which is still found as US-GA).
 // It returns matching cities/countires if any city/country matches the given string, otherwise an error.
-func getMatchingLocation(city, country string) ([]string, error) {
+func (r *Resolver) getMatchingLocation(city, country string) ([]string, error) {
 	if city != "" {
-		initializeCityTrie()
-		if found, matchingOriginalLocationNames := new_trie.searchWordWithPrefix(city); found {
+		if found, matchingOriginalLocationNames := searchWords(city, r.index(kindAlias), r.index(kindCity)); found {
 			return matchingOriginalLocationNames, nil
 		}
 		return nil, fmt.Errorf("City '%s' not found!", city)
 	} else {
-		initializeCountryTrie()
-		//check if the country is 2-letter alpha-2 code
-		if countryName, ok := tzdata.Alpha2ToCountry[strings.ToUpper(country)]; ok {
-			return []string{countryName}, nil
-		} else if countryName, ok := tzdata.Alpha3ToCountry[strings.ToUpper(country)]; ok { //check if the country is 3-letter alpha-3 code
+		//check if the country is a 2-letter alpha-2 or 3-letter alpha-3 code
+		if countryName := countryOfCode(country); countryName != "" {
 			return []string{countryName}, nil
-		} else if found, matchingOriginalLocationNames := new_trie.searchWordWithPrefix(country); found {
+		} else if _, ok := tzdata.SubdivisionToIanaTimezone[strings.ToUpper(country)]; ok { //check if it is an ISO 3166-2 subdivision code like US-CA
+			return []string{strings.ToUpper(country)}, nil
+		} else if found, matchingOriginalLocationNames := searchWords(country, r.index(kindCountry), r.index(kindSubdivision)); found {
 			return matchingOriginalLocationNames, nil
 		} else {
 			return nil, fmt.Errorf("Country '%s' not found!", country)
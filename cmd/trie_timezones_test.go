@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"sort"
 	"testing"
+
+	"github.com/kritibb/ktz/tzdata"
 )
 
 func TestInsertAndSearchWord(t *testing.T) {
@@ -94,14 +97,51 @@ func TestCleanWord(t *testing.T) {
 
 }
 
-func TestFindClosestMatches(t *testing.T) {
-	words := []string{"test", "testing", "tested", "tent", "kritib"}
-	got := findClosestMatches("test", words, 3)
+func TestClosestWords(t *testing.T) {
+	closest := newClosestWords(3)
+	for _, word := range []string{"testing", "test", "tested", "tent", "kritib", "tests"} {
+		closest.add(wordMatch{word: word, key: word, distance: levenshteinDistance("test", word)})
+	}
+	got := closest.sorted()
 	if len(got) != 3 {
 		t.Fatalf("Expected 3 matches, but got %d", len(got))
 	}
-	if got[0] != "test" {
-		t.Fatalf("Expected `test` as the closest match, but got %v", got[0])
+	if got[0].word != "test" || got[1].word != "tent" || got[2].word != "tests" {
+		t.Fatalf("Expected test, tent and tests as the closest matches, but got %v", got)
+	}
+}
+
+func TestRadixTree(t *testing.T) {
+	trie := newtrie()
+	for _, word := range []string{"Romania", "Rome", "Rom", "Romulus", "Rubens", "Ruber", "Rubicon", "Rubicundus"} {
+		trie.insertWord(word, word)
+	}
+	// the words share prefixes, so labels are split and merged nodes hold several characters
+	if got := len(trie.root.children); got != 1 || trie.root.children[0].label != "r" {
+		t.Fatalf("root children = %d, want the single label r", got)
+	}
+	tests := []struct {
+		prefix string
+		k      int
+		exact  string
+		want   []string
+	}{
+		{"rom", 0, "Rom", []string{"Rom", "Rome", "Romania", "Romulus"}},
+		{"rubi", 1, "", []string{"Rubicon"}},
+		{"rube", 0, "", []string{"Ruber", "Rubens"}},
+		{"romu", 0, "", []string{"Romulus"}},
+		{"romanians", 0, "", nil},
+		{"x", 0, "", nil},
+	}
+	for _, test := range tests {
+		exact, _, matches := trie.matchPrefix(test.prefix, test.k)
+		var got []string
+		for _, match := range matches {
+			got = append(got, match.word)
+		}
+		if exact != test.exact || !EqualSlices(got, test.want) {
+			t.Fatalf("`matchPrefix(%v, %v)` = %q, %v, want %q, %v", test.prefix, test.k, exact, got, test.exact, test.want)
+		}
 	}
 }
 
@@ -123,7 +163,7 @@ func TestGetMatchingLocationCity(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		gotCities, err := getMatchingLocation(test.given, "")
+		gotCities, err := DefaultResolver().getMatchingLocation(test.given, "")
         	if err != nil {
 			// Compare error messages
 			if err.Error() != test.want.err {
@@ -159,7 +199,7 @@ func TestGetMatchingLocationCountry(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		gotCountries, err := getMatchingLocation("",test.given)
+		gotCountries, err := DefaultResolver().getMatchingLocation("",test.given)
         	if err != nil {
 			// Compare error messages
 			if err.Error() != test.want.err {
@@ -176,3 +216,164 @@ func TestGetMatchingLocationCountry(t *testing.T) {
 	}
 
 }
+
+// mapTrieNode is a node of the former Trie with a map of children per character, kept to
+// compare the radix tree against in benchmarks.
+type mapTrieNode struct {
+	children     map[rune]*mapTrieNode
+	isWordEnd    bool
+	originalWord string
+}
+
+type mapTrie struct {
+	root *mapTrieNode
+}
+
+func newMapTrie() *mapTrie {
+	return &mapTrie{root: &mapTrieNode{children: make(map[rune]*mapTrieNode)}}
+}
+
+func (t *mapTrie) insertWord(word, original string) {
+	word = cleanWord(word)
+	node := t.root
+	for _, ch := range word {
+		if node.children[ch] == nil {
+			node.children[ch] = &mapTrieNode{children: make(map[rune]*mapTrieNode)}
+		}
+		node = node.children[ch]
+	}
+	node.isWordEnd = true
+	node.originalWord = original
+}
+
+func (t *mapTrie) searchWordWithPrefix(prefix string) (bool, []string) {
+	prefix = cleanWord(prefix)
+	node := t.root
+	for _, ch := range prefix {
+		if node.children[ch] == nil {
+			return false, []string{}
+		}
+		node = node.children[ch]
+	}
+	if node.isWordEnd {
+		return true, []string{node.originalWord}
+	}
+	words := collectAllMapWords(node, prefix)
+	if len(words) == 0 {
+		return false, []string{}
+	}
+	return true, findClosestMatches(prefix, words, 10)
+}
+
+func collectAllMapWords(node *mapTrieNode, prefix string) []string {
+	var words []string
+	if node.isWordEnd {
+		words = append(words, node.originalWord)
+	}
+	for char, childNode := range node.children {
+		words = append(words, collectAllMapWords(childNode, prefix+string(char))...)
+	}
+	return words
+}
+
+// findClosestMatches sorts all words by their Levenshtein distance to the target and returns
+// up to maxResults of them, as the former Trie did.
+func findClosestMatches(target string, words []string, maxResults int) []string {
+	type wordDistance struct {
+		word     string
+		distance int
+	}
+	var distances []wordDistance
+	for _, word := range words {
+		distances = append(distances, wordDistance{word: word, distance: levenshteinDistance(target, word)})
+	}
+	sort.Slice(distances, func(i, j int) bool {
+		return distances[i].distance < distances[j].distance
+	})
+	var closestMatches []string
+	for i := 0; i < min(maxResults, len(distances)); i++ {
+		closestMatches = append(closestMatches, distances[i].word)
+	}
+	return closestMatches
+}
+
+// levenshteinDistance calculates the minimum number of single-character edits (insertions,
+// deletions or substitutions) required to change one word into the other.
+func levenshteinDistance(s1, s2 string) int {
+	if len(s1) < len(s2) {
+		return levenshteinDistance(s2, s1)
+	}
+	if len(s2) == 0 {
+		return len(s1)
+	}
+	previousRow := make([]int, len(s2)+1)
+	for i := range previousRow {
+		previousRow[i] = i
+	}
+	for i := range s1 {
+		currentRow := make([]int, len(s2)+1)
+		currentRow[0] = i + 1
+		for j := range s2 {
+			deletionCost := previousRow[j+1] + 1
+			insertionCost := currentRow[j] + 1
+			substitutionCost := previousRow[j]
+			if s1[i] != s2[j] {
+				substitutionCost++
+			}
+			currentRow[j+1] = min(insertionCost, deletionCost, substitutionCost)
+		}
+		previousRow = currentRow
+	}
+	return previousRow[len(s2)]
+}
+
+// benchmarkCities are the city names the benchmarks insert.
+func benchmarkCities() []string {
+	return sortedKeys(tzdata.CityToIanaTimezone)
+}
+
+func BenchmarkRadixTreeInsert(b *testing.B) {
+	cities := benchmarkCities()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t := newtrie()
+		for _, city := range cities {
+			t.insertWord(city, city)
+		}
+	}
+}
+
+func BenchmarkMapTrieInsert(b *testing.B) {
+	cities := benchmarkCities()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		t := newMapTrie()
+		for _, city := range cities {
+			t.insertWord(city, city)
+		}
+	}
+}
+
+func BenchmarkRadixTreeSearch(b *testing.B) {
+	t := newtrie()
+	for _, city := range benchmarkCities() {
+		t.insertWord(city, city)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t.searchWordWithPrefix("sa")
+	}
+}
+
+func BenchmarkMapTrieSearch(b *testing.B) {
+	t := newMapTrie()
+	for _, city := range benchmarkCities() {
+		t.insertWord(city, city)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		t.searchWordWithPrefix("sa")
+	}
+}
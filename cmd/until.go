@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// UntilOptions holds the options of the `until` command.
+type UntilOptions struct {
+	Zone    string // The place of the time, instead of one given after the time
+	Live    bool   // Show a live countdown until the time is reached
+	Seconds bool   // Print only the remaining seconds, for scripts
+}
+
+// parseUntil parses the moment of the `until` command; a place given with 'zone'
+// replaces the one of the moment.
+func (r *Resolver) parseUntil(at, zone string) (moment, error) {
+	m, err := r.parseMoment(at)
+	if err != nil {
+		return m, err
+	}
+	if zone != "" {
+		if m.place != "" {
+			return m, fmt.Errorf("Give the place either after the time or with -z, not both.")
+		}
+		location, err := r.resolvePlace(zone)
+		if err != nil {
+			return m, err
+		}
+		if m.loc, err = loadLocation(location.timezone); err != nil {
+			return m, err
+		}
+		m.place = zone
+	}
+	return m, nil
+}
+
+// formatCountdown formats a duration like "74d 03h 12m 05s", with a leading '-' once it has passed.
+func formatCountdown(d time.Duration) string {
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+	d = d.Truncate(time.Second)
+	days := int(d / (24 * time.Hour))
+	hours := int(d/time.Hour) % 24
+	minutes := int(d/time.Minute) % 60
+	seconds := int(d/time.Second) % 60
+	if days > 0 {
+		return fmt.Sprintf("%s%dd %02dh %02dm %02ds", sign, days, hours, minutes, seconds)
+	}
+	return fmt.Sprintf("%s%02dh %02dm %02ds", sign, hours, minutes, seconds)
+}
+
+// TimeUntil prints the time from now until a moment like 'friday 17:00 London' or
+// '2026-12-31 23:59' in the place 'opts.Zone'. A time without a date that has passed
+// is the next one; the duration follows the DST changes in between.
+func TimeUntil(r *Resolver, at string, opts UntilOptions) {
+	m, err := r.parseUntil(at, opts.Zone)
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		return
+	}
+	now := time.Now()
+	target := m.next(now)
+	remaining := target.Sub(now)
+	title := fmt.Sprintf("%v (%v)", target.Format(config.shortTimeLayout()), m.loc)
+
+	switch {
+	case opts.Seconds:
+		fmt.Println(int64(remaining.Round(time.Second) / time.Second))
+	case config.Output == "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		encoder.Encode(map[string]any{
+			"Until":     target.Format(time.RFC3339),
+			"TimeZone":  m.loc.String(),
+			"Seconds":   int64(remaining.Round(time.Second) / time.Second),
+			"Remaining": formatCountdown(remaining),
+		})
+	case opts.Live && config.Output == "table" && remaining > 0:
+		if _, err := tea.NewProgram(countdownModel{title: title, target: target, now: time.Now}).Run(); err != nil {
+			fmt.Println("Error running program:", err)
+			os.Exit(1)
+		}
+	case remaining < 0:
+		fmt.Printf("%v was %v ago\n", title, formatCountdown(-remaining))
+	default:
+		fmt.Printf("%v is in %v\n", title, formatCountdown(remaining))
+	}
+}
+
+// tickMsg is sent every second to update the countdown.
+type tickMsg time.Time
+
+// countdownModel is a bubbletea model counting down to 'target'.
+type countdownModel struct {
+	title    string
+	target   time.Time
+	now      func() time.Time
+	done     bool
+	quitting bool
+}
+
+// countdownStyle is the style of the remaining time in the live countdown.
+var countdownStyle = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("170"))
+
+func tick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg { return tickMsg(t) })
+}
+
+func (m countdownModel) Init() tea.Cmd {
+	return tick()
+}
+
+func (m countdownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "q", "ctrl+c", "esc":
+			m.quitting = true
+			return m, tea.Quit
+		}
+	case tickMsg:
+		if !m.now().Before(m.target) {
+			m.done = true
+			return m, tea.Quit
+		}
+		return m, tick()
+	}
+	return m, nil
+}
+
+func (m countdownModel) View() string {
+	if m.done {
+		return quitTextStyle.Render(fmt.Sprintf("%v: time's up!", m.title))
+	}
+	remaining := formatCountdown(m.target.Sub(m.now()))
+	if m.quitting {
+		return quitTextStyle.Render(fmt.Sprintf("%v is in %v", m.title, remaining))
+	}
+	return fmt.Sprintf("\n  %v is in\n\n  %v\n\n%v\n", m.title, countdownStyle.Render(remaining), helpStyle.Render("q: quit"))
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestFormatCountdown(t *testing.T) {
+	tests := []struct {
+		given time.Duration
+		want  string
+	}{
+		{90 * time.Second, "00h 01m 30s"},
+		{26*time.Hour + 5*time.Minute + 1500*time.Millisecond, "1d 02h 05m 01s"},
+		{-3 * time.Minute, "-00h 03m 00s"},
+	}
+	for _, test := range tests {
+		if got := formatCountdown(test.given); got != test.want {
+			t.Fatalf("`formatCountdown(%v)` = %q, want %q", test.given, got, test.want)
+		}
+	}
+}
+
+func TestParseUntil(t *testing.T) {
+	m, err := DefaultResolver().parseUntil("2026-12-31 23:59", "Asia/Kathmandu")
+	if err != nil || m.loc.String() != "Asia/Kathmandu" || m.hour != 23 || m.minute != 59 {
+		t.Fatalf("`parseUntil()` = %+v, %v", m, err)
+	}
+	if _, err := DefaultResolver().parseUntil("09:00 Berlin", "Tokyo"); err == nil {
+		t.Fatal("`parseUntil()` accepted a place both after the time and with -z")
+	}
+	// the night the clocks go back in London lasts an hour longer
+	m, _ = DefaultResolver().parseUntil("2026-10-25 09:00", "London")
+	now := time.Date(2026, 10, 24, 9, 0, 0, 0, m.loc)
+	if got := m.next(now).Sub(now); got != 25*time.Hour {
+		t.Fatalf("time until 2026-10-25 09:00 London = %v, want 25h", got)
+	}
+}
+
+func TestCountdownModel(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	m := countdownModel{title: "Fri", target: now.Add(90 * time.Second), now: func() time.Time { return now }}
+	if view := m.View(); !strings.Contains(view, "00h 01m 30s") {
+		t.Fatalf("`View()` = %q", view)
+	}
+	updated, cmd := m.Update(tickMsg(now))
+	if updated.(countdownModel).done || cmd == nil {
+		t.Fatal("the countdown stopped before the target")
+	}
+	now = now.Add(90 * time.Second)
+	updated, _ = m.Update(tickMsg(now))
+	if !updated.(countdownModel).done || !strings.Contains(updated.View(), "time's up") {
+		t.Fatalf("the countdown didn't stop at the target: %q", updated.View())
+	}
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	if !updated.(countdownModel).quitting {
+		t.Fatal("`q` didn't quit the countdown")
+	}
+}
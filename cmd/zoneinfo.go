@@ -0,0 +1,228 @@
+package cmd
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// zoneSource is a place timezone data is loaded from: a zoneinfo directory,
+// a zoneinfo.zip file or the snapshot embedded in ktz.
+type zoneSource struct {
+	name    string // A description like "system: /usr/share/zoneinfo" or "embedded"
+	version string // The IANA tzdata release like "2026c", empty if unknown
+	read    func(zone string) ([]byte, error)
+}
+
+// systemZoneinfoDirs are the directories the host's zoneinfo is usually installed in.
+var systemZoneinfoDirs = []string{"/usr/share/zoneinfo", "/usr/share/lib/zoneinfo", "/usr/lib/locale/TZ"}
+
+var (
+	zoneSources   = defaultZoneSources("") // The sources tried in order by loadLocation
+	zoneCache     = map[string]*time.Location{}
+	zoneCacheLock sync.Mutex
+)
+
+// SetZoneinfo sets where timezone data is loaded from. The 'path' parameter is a
+// zoneinfo directory or zip file tried before the host's zoneinfo and the embedded
+// snapshot, and an error if it's missing; if empty, $ZONEINFO is used instead.
+// Like the standard library, a missing $ZONEINFO is ignored, with a warning.
+func SetZoneinfo(path string) error {
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return fmt.Errorf("tzdata: %v", err)
+		}
+	} else if env := os.Getenv("ZONEINFO"); env != "" {
+		if _, err := os.Stat(env); err != nil {
+			fmt.Fprintf(os.Stderr, "\nWarning: ignoring $ZONEINFO: %v\n", err)
+		} else {
+			path = env
+		}
+	}
+	zoneCacheLock.Lock()
+	defer zoneCacheLock.Unlock()
+	zoneSources = defaultZoneSources(path)
+	zoneCache = map[string]*time.Location{}
+	return nil
+}
+
+// defaultZoneSources returns the sources to load timezone data from: the given
+// zoneinfo directory or zip file, if any, the host's zoneinfo and the embedded snapshot.
+func defaultZoneSources(path string) []zoneSource {
+	var sources []zoneSource
+	if path != "" {
+		sources = append(sources, pathZoneSource(path))
+	}
+	for _, dir := range systemZoneinfoDirs {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			source := dirZoneSource(dir)
+			source.name = "system: " + dir
+			sources = append(sources, source)
+			break
+		}
+	}
+	return append(sources, embeddedZoneSource())
+}
+
+// pathZoneSource returns the source for a zoneinfo directory or zip file.
+func pathZoneSource(path string) zoneSource {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return dirZoneSource(path)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return zoneSource{name: path, read: func(string) ([]byte, error) { return nil, err }}
+	}
+	source := zipZoneSource(content)
+	source.name = path
+	return source
+}
+
+// dirZoneSource returns the source for a zoneinfo directory like /usr/share/zoneinfo.
+func dirZoneSource(dir string) zoneSource {
+	return zoneSource{
+		name:    dir,
+		version: readTzdataVersion(func(file string) ([]byte, error) { return os.ReadFile(filepath.Join(dir, file)) }),
+		read: func(zone string) ([]byte, error) {
+			return os.ReadFile(filepath.Join(dir, filepath.FromSlash(zone)))
+		},
+	}
+}
+
+// zipZoneSource returns the source for the content of a zoneinfo.zip file.
+func zipZoneSource(content []byte) zoneSource {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return zoneSource{read: func(string) ([]byte, error) { return nil, err }}
+	}
+	read := func(zone string) ([]byte, error) {
+		file, err := archive.Open(zone)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+	return zoneSource{version: readTzdataVersion(read), read: read}
+}
+
+// embeddedZoneSource returns the source for the snapshot embedded in ktz.
+func embeddedZoneSource() zoneSource {
+	source := zipZoneSource(tzdata.ZoneinfoZip)
+	source.name = "embedded"
+	source.version = tzdata.ZoneinfoVersion
+	return source
+}
+
+// readTzdataVersion returns the tzdata release recorded in a zoneinfo tree, either in
+// its "+VERSION" file or in the "# version" header of its "tzdata.zi" file.
+func readTzdataVersion(read func(file string) ([]byte, error)) string {
+	if content, err := read("+VERSION"); err == nil {
+		return strings.TrimSpace(string(content))
+	}
+	if content, err := read("tzdata.zi"); err == nil {
+		line, _ := bufio.NewReader(bytes.NewReader(content)).ReadString('\n')
+		if version, ok := strings.CutPrefix(strings.TrimSpace(line), "# version "); ok {
+			return version
+		}
+	}
+	return ""
+}
+
+// isValidZoneName reports whether 'zone' can safely be used as a path in a zoneinfo tree.
+func isValidZoneName(zone string) bool {
+	if zone == "" || strings.HasPrefix(zone, "/") || strings.Contains(zone, "\\") {
+		return false
+	}
+	for _, part := range strings.Split(zone, "/") {
+		if part == "" || part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// readZoneData returns the TZif data of a timezone from the first source that has it,
+// together with that source.
+func readZoneData(zone string) ([]byte, zoneSource, error) {
+	if !isValidZoneName(zone) {
+		return nil, zoneSource{}, fmt.Errorf("unknown time zone %s", zone)
+	}
+	zoneCacheLock.Lock()
+	sources := zoneSources
+	zoneCacheLock.Unlock()
+	for _, source := range sources {
+		if data, err := source.read(zone); err == nil {
+			return data, source, nil
+		}
+	}
+	return nil, zoneSource{}, fmt.Errorf("unknown time zone %s", zone)
+}
+
+// loadLocation returns the location of a timezone name like 'Asia/Kathmandu'.
+// Unlike time.LoadLocation, it falls back to the embedded snapshot when the host
+// has no zoneinfo, honours the zoneinfo set with SetZoneinfo and also accepts POSIX
+// TZ strings like 'EST5EDT,M3.2.0,M11.1.0'.
+func loadLocation(zone string) (*time.Location, error) {
+	if zone == "" || zone == "UTC" || zone == "Local" {
+		return time.LoadLocation(zone)
+	}
+	zoneCacheLock.Lock()
+	loc, ok := zoneCache[zone]
+	zoneCacheLock.Unlock()
+	if ok {
+		return loc, nil
+	}
+	data, _, err := readZoneData(zone)
+	if err == nil {
+		loc, err = time.LoadLocationFromTZData(zone, data)
+	} else if isPOSIXTZ(zone) {
+		loc, err = posixLocation(zone)
+	}
+	if err != nil {
+		return nil, err
+	}
+	zoneCacheLock.Lock()
+	zoneCache[zone] = loc
+	zoneCacheLock.Unlock()
+	return loc, nil
+}
+
+// knownZones returns the names of the timezones in the embedded snapshot, sorted.
+func knownZones() []string {
+	archive, err := zip.NewReader(bytes.NewReader(tzdata.ZoneinfoZip), int64(len(tzdata.ZoneinfoZip)))
+	if err != nil {
+		return nil
+	}
+	var zones []string
+	for _, file := range archive.File {
+		if isValidZoneName(file.Name) && !strings.HasPrefix(file.Name, "+") && !strings.HasSuffix(file.Name, "/") {
+			zones = append(zones, file.Name)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// TzdataVersion describes the timezone data in use, e.g. "2026c (embedded)".
+func TzdataVersion() string {
+	_, source, err := readZoneData("Etc/UTC")
+	if err != nil {
+		return "unknown"
+	}
+	version := source.version
+	if version == "" {
+		version = "unknown version"
+	}
+	return fmt.Sprintf("%s (%s)", version, source.name)
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// withZoneSources sets the timezone data sources for the duration of a test.
+func withZoneSources(t *testing.T, sources ...zoneSource) {
+	t.Helper()
+	previous := zoneSources
+	zoneSources = sources
+	zoneCache = map[string]*time.Location{}
+	t.Cleanup(func() {
+		zoneSources = previous
+		zoneCache = map[string]*time.Location{}
+	})
+}
+
+func TestLoadLocationEmbedded(t *testing.T) {
+	// no system zoneinfo, as in a minimal container
+	withZoneSources(t, embeddedZoneSource())
+
+	for _, zone := range []string{"Asia/Kathmandu", "America/New_York", "Australia/Lord_Howe"} {
+		if _, err := loadLocation(zone); err != nil {
+			t.Fatalf("loadLocation(%v) returned error %v", zone, err)
+		}
+	}
+	if _, err := DefaultResolver().formatTime("Asia/Kathmandu", time.Now()); err != nil {
+		t.Fatalf("formatTime(Asia/Kathmandu) returned error %v", err)
+	}
+	if _, err := loadLocation("Invalid/Timezone"); err == nil {
+		t.Fatalf("loadLocation(Invalid/Timezone) returned no error")
+	}
+	if got, want := TzdataVersion(), "2026c (embedded)"; got != want {
+		t.Fatalf("TzdataVersion() = %v, want %v", got, want)
+	}
+}
+
+func TestSetZoneinfoZip(t *testing.T) {
+	embedded := embeddedZoneSource()
+	path := filepath.Join(t.TempDir(), "zoneinfo.zip")
+	file, _ := os.Create(path)
+	archive := zip.NewWriter(file)
+	for _, zone := range []string{"Etc/UTC", "Asia/Kathmandu"} {
+		data, _ := embedded.read(zone)
+		w, _ := archive.Create(zone)
+		w.Write(data)
+	}
+	w, _ := archive.Create("+VERSION")
+	w.Write([]byte("2099z\n"))
+	archive.Close()
+	file.Close()
+
+	withZoneSources(t, zoneSources...)
+	t.Setenv("ZONEINFO", path)
+	if err := SetZoneinfo(""); err != nil {
+		t.Fatalf("SetZoneinfo() returned error %v", err)
+	}
+	if got, want := TzdataVersion(), "2099z ("+path+")"; got != want {
+		t.Fatalf("TzdataVersion() = %v, want %v", got, want)
+	}
+	if _, err := loadLocation("Asia/Kathmandu"); err != nil {
+		t.Fatalf("loadLocation(Asia/Kathmandu) returned error %v", err)
+	}
+	if err := SetZoneinfo(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatalf("SetZoneinfo(missing) returned no error")
+	}
+}
+
+func TestSetZoneinfoMissingEnv(t *testing.T) {
+	withZoneSources(t, zoneSources...)
+	// a stale $ZONEINFO falls back to the other sources, like in the standard library
+	t.Setenv("ZONEINFO", filepath.Join(t.TempDir(), "missing"))
+	if err := SetZoneinfo(""); err != nil {
+		t.Fatalf("SetZoneinfo() with a missing $ZONEINFO returned error %v", err)
+	}
+	if _, err := loadLocation("Asia/Kathmandu"); err != nil {
+		t.Fatalf("loadLocation(Asia/Kathmandu) returned error %v", err)
+	}
+}
+
+func TestIsValidZoneName(t *testing.T) {
+	tests := []struct {
+		given string
+		want  bool
+	}{
+		{given: "Asia/Kathmandu", want: true},
+		{given: "America/Argentina/Buenos_Aires", want: true},
+		{given: "../../etc/passwd", want: false},
+		{given: "/etc/localtime", want: false},
+		{given: "Asia//Kathmandu", want: false},
+	}
+	for _, test := range tests {
+		if got := isValidZoneName(test.given); got != test.want {
+			t.Fatalf("`isValidZoneName(%v)=%v`, want %v", test.given, got, test.want)
+		}
+	}
+}
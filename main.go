@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"github.com/kritibb/ktz/cmd"
 	"os"
+	"runtime/debug"
 	"strings"
+	"time"
 )
 
 // main is the entry point of the application.
@@ -16,23 +18,117 @@ import (
 // retrieves the corresponding timezone, and displays the current local time in that timezone.
 func main() {
 
+	//define global flags, given before the subcommand
+	globalCmd := flag.NewFlagSet("ktz", flag.ExitOnError)
+	tzdataDir := globalCmd.String("tzdata-dir", "", "zoneinfo `directory or zip` to load timezones from (default $ZONEINFO)")
+	globalCmd.Parse(os.Args[1:])
+	args := globalCmd.Args()
+
 	//define subcommand `lookup` and its flags
 	lookupCmd := flag.NewFlagSet("lookup", flag.ExitOnError)
-	lookupC := lookupCmd.String("c", "", "country name/code like `Nepal` or `NP`")
-	lookupZ := lookupCmd.String("z", "", "`timezones` like `Asia/Kathmandu` or `PST`")
+	lookupC := lookupCmd.String("c", "", "country name/code like `Nepal` or `NP`, or a subdivision like `US-CA`")
+	lookupZ := lookupCmd.String("z", "", "`timezones` like `Asia/Kathmandu`, `PST` or `EST5EDT,M3.2.0,M11.1.0`")
+	lookupHome := lookupCmd.String("home", "", "`place` the time difference is shown against (default: system zone)")
+	lookupDaylight := lookupCmd.Bool("daylight", false, "show sunrise, sunset, solar noon and day length")
+	lookupStrictAbbr := lookupCmd.Bool("strict-abbr", false, "take an abbreviation like `PST` as its fixed UTC offset, even in summer")
 
-	//define subcommand `lookup` and its flags
+	//define subcommand `search` and its flags
+	searchCmd := flag.NewFlagSet("search", flag.ExitOnError)
+	searchLimit := searchCmd.Int("n", 20, "maximum `number` of results")
+
+	//define subcommand `diff`
+	diffCmd := flag.NewFlagSet("diff", flag.ExitOnError)
+
+	//define subcommand `config`
+	configCmd := flag.NewFlagSet("config", flag.ExitOnError)
+
+	//define subcommand `alias`
+	aliasCmd := flag.NewFlagSet("alias", flag.ExitOnError)
+
+	//define subcommand `cal` and its flags
+	calCmd := flag.NewFlagSet("cal", flag.ExitOnError)
+	calMonth := calCmd.String("month", "", "`month` like 2026-11 (default: this month)")
+	calAt := calCmd.String("at", "", "`time` like \"09:30 Kathmandu\" to show converted on each day")
+
+	//define subcommand `convert` and its flags
+	convertCmd := flag.NewFlagSet("convert", flag.ExitOnError)
+	convertICS := convertCmd.Bool("ics", false, "print an iCalendar event instead of a table")
+	convertTitle := convertCmd.String("title", "Meeting", "`summary` of the iCalendar event")
+	convertDuration := convertCmd.Duration("duration", time.Hour, "`duration` of the iCalendar event")
+	convertDaylight := convertCmd.Bool("daylight", false, "show whether it's day or night in each place")
+
+	//define subcommand `vtimezone` and its flags
+	vtimezoneCmd := flag.NewFlagSet("vtimezone", flag.ExitOnError)
+	vtimezoneFrom := vtimezoneCmd.Int("from", time.Now().Year(), "first `year` the component must cover")
+
+	//define subcommand `until` and its flags
+	untilCmd := flag.NewFlagSet("until", flag.ExitOnError)
+	untilZ := untilCmd.String("z", "", "`place` or timezone of the time, like Asia/Kathmandu")
+	untilLive := untilCmd.Bool("live", false, "show a live countdown")
+	untilSeconds := untilCmd.Bool("seconds", false, "print only the remaining seconds")
+
+	//define subcommand `holidays` and its flags
+	holidaysCmd := flag.NewFlagSet("holidays", flag.ExitOnError)
+	holidaysC := holidaysCmd.String("c", "", "country name/code like `Nepal` or `NP`")
+	holidaysYear := holidaysCmd.Int("year", time.Now().Year(), "`year` to list the holidays of")
+
+	//define subcommand `ok-to-call`
+	okToCallCmd := flag.NewFlagSet("ok-to-call", flag.ExitOnError)
+
+	//define subcommand `posix`
+	posixCmd := flag.NewFlagSet("posix", flag.ExitOnError)
+
+	//define subcommand `reverse`
+	reverseCmd := flag.NewFlagSet("reverse", flag.ExitOnError)
+
+	//define subcommand `explore` and its flags
+	exploreCmd := flag.NewFlagSet("explore", flag.ExitOnError)
+	exploreHome := exploreCmd.String("home", "", "`place` the time difference is shown against (default: system zone)")
+	exploreDaylight := exploreCmd.Bool("daylight", false, "show sunrise, sunset, solar noon and day length")
+
+	//define subcommand `list` and its flags
+	listCmd := flag.NewFlagSet("list", flag.ExitOnError)
+	listCountry := listCmd.String("country", "", "only entries of a country `name/code` like NP")
+	listOffset := listCmd.String("offset", "", "only entries at a UTC `offset` now, like +5:45")
+	listRegion := listCmd.String("region", "", "only entries with a zone in a `region` like Europe")
+	listDST := listCmd.Bool("dst", false, "only entries with a zone observing DST")
+	listSort := listCmd.String("sort", "name", "sort by `name`, offset, country or region")
+	listOutput := listCmd.String("o", "", "output `format`: table, plain, json or csv (default: the configured output)")
+
+	//define subcommand `inspect`
+	inspectCmd := flag.NewFlagSet("inspect", flag.ExitOnError)
+
+	//define subcommand `version` and its flags
+	versionCmd := flag.NewFlagSet("version", flag.ExitOnError)
+	versionTzdata := versionCmd.Bool("tzdata", false, "also show the tzdata version in use")
+
+	//define subcommand `help`
 	helpCmd := flag.NewFlagSet("help", flag.ExitOnError)
 
 	// Check if subcommands like "lookup" is provided
-	if len(os.Args) < 2 {
+	if len(args) < 1 {
 		printError("", "\n Error: Incomplete command")
 		return
 	}
 
-	switch os.Args[1] {
+	if err := cmd.SetZoneinfo(*tzdataDir); err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		os.Exit(1)
+	}
+
+	// load the user's defaults; a broken config file can still be fixed with `ktz config`
+	if err := cmd.LoadConfig(); err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		if args[0] != "config" {
+			os.Exit(1)
+		}
+	}
+	// the places, aliases and abbreviations of the configuration are indexed once per run
+	r := cmd.DefaultResolver()
+
+	switch args[0] {
 	case "lookup":
-		lookupCmd.Parse(os.Args[2:])
+		lookupCmd.Parse(args[1:])
 		//no flags or positional argument provided
 		if *lookupZ == "" && *lookupC == "" && len(lookupCmd.Args()) == 0 {
 			printError("lookup", "\n Error: Incomplete command")
@@ -46,20 +142,188 @@ func main() {
 			return
 		}
 
+		opts := cmd.LookupOptions{Home: *lookupHome, Daylight: *lookupDaylight, StrictAbbr: *lookupStrictAbbr}
 		//handle -z flag
 		if *lookupZ != "" {
-			cmd.ResolveTimezone("", "", *lookupZ)
+			cmd.ResolveTimezone(r, "", "", *lookupZ, opts)
 		} else if *lookupC != "" { //handle -c flag
-			cmd.ResolveTimezone("", *lookupC, "")
+			cmd.ResolveTimezone(r, "", *lookupC, "", opts)
 		} else if len(lookupCmd.Args()) != 0 {
-			// combine all non-flag arguments to create a city name
-			city := strings.Join(lookupCmd.Args(), " ")
-			// Display the current local time based on the given city
-			cmd.ResolveTimezone(city, "", "")
+			// combine all non-flag arguments to create a place name
+			place := strings.Join(lookupCmd.Args(), " ")
+			// Display the current local time of the city, country, zone or abbreviation it names best
+			cmd.Lookup(r, place, opts)
+		}
+	case "search":
+		searchArgs := parseInterspersed(searchCmd, args[1:])
+		if len(searchArgs) == 0 {
+			printError("search", "\n Error: Expected a query")
+			return
+		}
+		cmd.Search(r, strings.Join(searchArgs, " "), *searchLimit)
+	case "diff":
+		diffCmd.Parse(args[1:])
+		if len(diffCmd.Args()) != 2 {
+			printError("diff", "\n Error: Expected exactly two places")
+			return
+		}
+		cmd.ShowDifference(r, diffCmd.Arg(0), diffCmd.Arg(1))
+	case "config":
+		configCmd.Parse(args[1:])
+		runConfig(configCmd.Args())
+	case "alias":
+		aliasCmd.Parse(args[1:])
+		runAlias(r, aliasCmd.Args())
+	case "cal":
+		calCmd.Parse(args[1:])
+		if len(calCmd.Args()) == 0 {
+			printError("cal", "\n Error: Expected at least one place")
+			return
+		}
+		cmd.ShowCalendar(r, calCmd.Args(), cmd.CalendarOptions{Month: *calMonth, At: *calAt})
+	case "convert":
+		convertCmd.Parse(args[1:])
+		if len(convertCmd.Args()) == 0 {
+			printError("convert", "\n Error: Expected a time like \"09:30 Kathmandu\"")
+			return
+		}
+		opts := cmd.ConvertOptions{ICS: *convertICS, Title: *convertTitle, Duration: *convertDuration, Daylight: *convertDaylight}
+		cmd.ConvertTime(r, convertCmd.Arg(0), convertCmd.Args()[1:], opts)
+	case "vtimezone":
+		vtimezoneCmd.Parse(args[1:])
+		if len(vtimezoneCmd.Args()) == 0 {
+			printError("vtimezone", "\n Error: Expected a place")
+			return
+		}
+		cmd.ShowVTimezone(r, strings.Join(vtimezoneCmd.Args(), " "), *vtimezoneFrom)
+	case "until":
+		untilArgs := parseInterspersed(untilCmd, args[1:])
+		if len(untilArgs) == 0 {
+			printError("until", "\n Error: Expected a time like \"friday 17:00 London\"")
+			return
+		}
+		opts := cmd.UntilOptions{Zone: *untilZ, Live: *untilLive, Seconds: *untilSeconds}
+		cmd.TimeUntil(r, strings.Join(untilArgs, " "), opts)
+	case "holidays":
+		holidaysCmd.Parse(args[1:])
+		country := *holidaysC
+		if country == "" {
+			country = strings.Join(holidaysCmd.Args(), " ")
+		}
+		if country == "" {
+			printError("holidays", "\n Error: Expected a country like -c NP")
+			return
+		}
+		cmd.ShowHolidays(r, country, *holidaysYear)
+	case "ok-to-call":
+		okToCallCmd.Parse(args[1:])
+		if len(okToCallCmd.Args()) == 0 {
+			printError("ok-to-call", "\n Error: Expected a place")
+			os.Exit(2)
+		}
+		// exit with 1 outside working hours and 2 on errors, for scripts
+		ok, err := cmd.OkToCall(r, strings.Join(okToCallCmd.Args(), " "))
+		if err != nil {
+			fmt.Printf("\nError: %v\n", err)
+			os.Exit(2)
+		}
+		if !ok {
+			os.Exit(1)
+		}
+	case "posix":
+		posixCmd.Parse(args[1:])
+		if len(posixCmd.Args()) == 0 {
+			printError("posix", "\n Error: Expected a place")
+			return
 		}
-    case "help":
-        helpCmd.Parse(os.Args[2:])
-        printLookupHelp()
+		cmd.ShowPOSIX(r, strings.Join(posixCmd.Args(), " "))
+	case "reverse":
+		reverseArgs := args[1:]
+		// a negative offset like -05:00 isn't a flag
+		if len(reverseArgs) == 0 || !isNegativeNumber(reverseArgs[0]) {
+			reverseCmd.Parse(args[1:])
+			reverseArgs = reverseCmd.Args()
+		}
+		if len(reverseArgs) == 0 {
+			printError("reverse", "\n Error: Expected a zone or a UTC offset")
+			return
+		}
+		cmd.ReverseLookup(r, strings.Join(reverseArgs, " "))
+	case "explore":
+		exploreCmd.Parse(args[1:])
+		if len(exploreCmd.Args()) != 0 {
+			printError("explore", "\n Error: Expected no arguments")
+			return
+		}
+		opts := cmd.LookupOptions{Home: *exploreHome, Daylight: *exploreDaylight}
+		if err := cmd.Explore(r, opts); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+		}
+	case "list":
+		listArgs := parseInterspersed(listCmd, args[1:])
+		if len(listArgs) != 1 {
+			printError("list", "\n Error: Expected one of countries, cities, zones or abbreviations")
+			return
+		}
+		opts := cmd.ListOptions{
+			Country: *listCountry,
+			Offset:  *listOffset,
+			Region:  *listRegion,
+			DST:     *listDST,
+			Sort:    *listSort,
+			Output:  *listOutput,
+		}
+		if err := cmd.List(r, listArgs[0], opts); err != nil {
+			fmt.Printf("\nError: %v\n", err)
+		}
+	case "inspect":
+		inspectCmd.Parse(args[1:])
+		if len(inspectCmd.Args()) != 1 {
+			printError("inspect", "\n Error: Expected exactly one TZif file")
+			return
+		}
+		cmd.InspectTZif(inspectCmd.Arg(0))
+	case "version":
+		versionCmd.Parse(args[1:])
+		fmt.Println("ktz", ktzVersion())
+		if *versionTzdata {
+			fmt.Println("tzdata", cmd.TzdataVersion())
+		}
+	case "help":
+		helpCmd.Parse(args[1:])
+		printLookupHelp()
+		fmt.Println()
+		printSearchHelp()
+		fmt.Println()
+		printDiffHelp()
+		fmt.Println()
+		printConfigHelp()
+		fmt.Println()
+		printAliasHelp()
+		fmt.Println()
+		printCalHelp()
+		fmt.Println()
+		printConvertHelp()
+		fmt.Println()
+		printVTimezoneHelp()
+		fmt.Println()
+		printOkToCallHelp()
+		fmt.Println()
+		printHolidaysHelp()
+		fmt.Println()
+		printUntilHelp()
+		fmt.Println()
+		printPOSIXHelp()
+		fmt.Println()
+		printReverseHelp()
+		fmt.Println()
+		printExploreHelp()
+		fmt.Println()
+		printListHelp()
+		fmt.Println()
+		printInspectHelp()
+		fmt.Println()
+		printVersionHelp()
 
 	default:
 		printError("", "\n Error: Unknown command")
@@ -100,11 +364,118 @@ func main() {
 
 }
 
+// runConfig runs the `config get/set/list` subcommands with the given arguments.
+func runConfig(args []string) {
+	if len(args) == 0 {
+		printError("config", "\n Error: Incomplete command")
+		return
+	}
+	var err error
+	switch {
+	case args[0] == "get" && len(args) == 2:
+		err = cmd.ConfigGet(args[1])
+	case args[0] == "set" && len(args) >= 3:
+		err = cmd.ConfigSet(args[1], args[2:])
+	case args[0] == "list" && len(args) == 1:
+		cmd.ConfigList()
+	default:
+		printError("config", "\n Error: Unknown or incomplete config command")
+		return
+	}
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runAlias runs the `alias set/remove/list` subcommands with the given arguments.
+func runAlias(r *cmd.Resolver, args []string) {
+	if len(args) == 0 {
+		printError("alias", "\n Error: Incomplete command")
+		return
+	}
+	var err error
+	switch {
+	case args[0] == "set" && len(args) >= 3:
+		err = cmd.SetAlias(args[1], args[2:])
+	case args[0] == "remove" && len(args) == 2:
+		err = cmd.RemoveAlias(r, args[1])
+	case args[0] == "list" && len(args) == 1:
+		cmd.ListAliases()
+	default:
+		printError("alias", "\n Error: Unknown or incomplete alias command")
+		return
+	}
+	if err != nil {
+		fmt.Printf("\nError: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// parseInterspersed parses the flags of 'fs' given anywhere among the arguments,
+// e.g. `until "2026-12-31 23:59" -z Asia/Kathmandu`, and returns the other arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+	for {
+		fs.Parse(args)
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+// isNegativeNumber reports whether a command line argument is a negative number like -5
+// or -05:00 rather than a flag.
+func isNegativeNumber(arg string) bool {
+	return len(arg) > 1 && arg[0] == '-' && arg[1] >= '0' && arg[1] <= '9'
+}
+
+// ktzVersion returns the version of the ktz module the binary was built from.
+func ktzVersion() string {
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	return "(devel)"
+}
+
 func printError(errCmd, errMsg string) {
 	fmt.Println(errMsg)
 	switch errCmd {
 	case "lookup":
-		fmt.Println(" Usage: ktz lookup [options] <city>")
+		fmt.Println(" Usage: ktz lookup [options] <place>")
+	case "search":
+		fmt.Println(" Usage: ktz search [-n number] <query>")
+	case "diff":
+		fmt.Println(" Usage: ktz diff <placeA> <placeB>")
+	case "config":
+		fmt.Println(" Usage: ktz config get <key> | set <key> <value> | list")
+	case "alias":
+		fmt.Println(" Usage: ktz alias set <name> <places...> | remove <name> | list")
+	case "cal":
+		fmt.Println(" Usage: ktz cal [options] <places...>")
+	case "convert":
+		fmt.Println(" Usage: ktz convert [options] <time> [places...]")
+	case "vtimezone":
+		fmt.Println(" Usage: ktz vtimezone [-from year] <place>")
+	case "ok-to-call":
+		fmt.Println(" Usage: ktz ok-to-call <place>")
+	case "holidays":
+		fmt.Println(" Usage: ktz holidays [-year year] -c <country>")
+	case "until":
+		fmt.Println(" Usage: ktz until [options] <time>")
+	case "posix":
+		fmt.Println(" Usage: ktz posix <place>")
+	case "reverse":
+		fmt.Println(" Usage: ktz reverse <zone|offset>")
+	case "explore":
+		fmt.Println(" Usage: ktz explore [-home place] [-daylight]")
+	case "list":
+		fmt.Println(" Usage: ktz list [options] <countries|cities|zones|abbreviations>")
+	case "inspect":
+		fmt.Println(" Usage: ktz inspect <file>")
 	default:
 	}
 	fmt.Println(" For more information, try 'ktz help'")
@@ -113,20 +484,260 @@ func printError(errCmd, errMsg string) {
 }
 
 func printLookupHelp() {
-	fmt.Println("Usage: ktz lookup [options] <city>")
+	fmt.Println("Usage: ktz lookup [options] <place>")
 	fmt.Println()
-	fmt.Println("Look up the current time for a city,zone or country")
+	fmt.Println("Look up the current time for a city,zone or country;")
+	fmt.Println("a <place> may be any of them, like ktz search finds it")
 	fmt.Println()
 	fmt.Println("Options:")
-	fmt.Println("  -z string  Specify a timezone")
-	fmt.Println("  -c string  Specify a country name or alpha2/alpha3 code")
+	fmt.Println("  -z string  Specify a timezone, abbreviation or POSIX TZ string")
+	fmt.Println("  -c string  Specify a country name or alpha2/alpha3 code, or a state/province")
+	fmt.Println("  -home string  Show the difference to this place instead of the system zone")
+	fmt.Println("  -daylight  Show sunrise, sunset, solar noon and day length (a Daylight column for aliases)")
+	fmt.Println("  -strict-abbr  Take an abbreviation like PST as its fixed UTC offset, even when it isn't in effect")
 	fmt.Println()
 	fmt.Println("Examples:")
 	fmt.Println("  ktz lookup \"New York\"")
+	fmt.Println("  ktz lookup nepal or ktz lookup pst")
 	fmt.Println("  ktz lookup -z=America/New_York or -z=PST")
+	fmt.Println("  ktz lookup -strict-abbr -z=PST")
 	fmt.Println("  ktz lookup -c=NP or -c=Nepal")
+	fmt.Println("  ktz lookup -c=US-CA or -c=\"Western Australia\"")
+	fmt.Println("  ktz lookup -home=Kathmandu Sydney")
+}
+
+func printSearchHelp() {
+	fmt.Println("Usage: ktz search [-n number] <query>")
+	fmt.Println()
+	fmt.Println("Search cities, countries, states/provinces, zones, abbreviations and aliases at once;")
+	fmt.Println("exact matches come first with what they belong to, then names starting with the query")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -n int  Maximum number of results (default 20)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz search kathmandu")
+	fmt.Println("  ktz search -n 5 san")
+}
+
+func printDiffHelp() {
+	fmt.Println("Usage: ktz diff <placeA> <placeB>")
+	fmt.Println()
+	fmt.Println("Show the time difference between two cities, countries or zones,")
+	fmt.Println("now and after the next DST change of either place")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz diff Kathmandu \"New York\"")
+	fmt.Println("  ktz diff Asia/Kathmandu PST")
+}
+
+func printConfigHelp() {
+	fmt.Println("Usage: ktz config get <key> | set <key> <value> | list")
+	fmt.Println()
+	fmt.Println("Read or change the defaults in ~/.config/ktz/config.toml (or $KTZ_CONFIG)")
+	fmt.Println()
+	fmt.Println("Keys:")
+	fmt.Println("  format             Go time layout like \"Mon, 02 Jan 2006 15:04\" ($KTZ_FORMAT)")
+	fmt.Println("  clock              12 or 24 ($KTZ_CLOCK)")
+	fmt.Println("  home               Place time differences are shown against ($KTZ_HOME)")
+	fmt.Println("  output             table, plain or json ($KTZ_OUTPUT)")
+	fmt.Println("  abbreviations.<A>  Timezone the abbreviation <A> stands for")
+	fmt.Println("  aliases.<name>     Places the alias <name> stands for (see ktz alias)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz config set clock 24")
+	fmt.Println("  ktz config set abbreviations.IST Asia/Kolkata")
+	fmt.Println("  ktz config get home")
+}
+
+func printAliasHelp() {
+	fmt.Println("Usage: ktz alias set <name> <places...> | remove <name> | list")
+	fmt.Println()
+	fmt.Println("Name a place or a group of places; aliases work everywhere a city does")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz alias set berlin-office Europe/Berlin")
+	fmt.Println("  ktz alias set apac Tokyo Singapore Sydney")
+	fmt.Println("  ktz lookup apac")
+}
+
+func printCalHelp() {
+	fmt.Println("Usage: ktz cal [options] <places...>")
+	fmt.Println()
+	fmt.Println("Show a month calendar for each place, marking the days its UTC offset changes.")
+	fmt.Println("With -at, each day shows that time converted to the place, so the weeks a")
+	fmt.Println("meeting drifts by an hour stand out")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -month string  Month like 2026-11 (default: this month)")
+	fmt.Println("  -at string     Time and place like \"09:30 Kathmandu\" (default place: home)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz cal -month 2026-03 Berlin \"New York\"")
+	fmt.Println("  ktz cal -month 2026-11 -at \"09:30 Kathmandu\" London \"New York\" Sydney")
+}
+
+func printOkToCallHelp() {
+	fmt.Println("Usage: ktz ok-to-call <place>")
+	fmt.Println()
+	fmt.Println("Show whether it's working hours, morning, evening, night or weekend in a place.")
+	fmt.Println("Exits with 0 within working hours, 1 outside them and 2 on errors, e.g. for")
+	fmt.Println("paging scripts. Working hours default to 09:00-17:00 on the weekdays of the")
+	fmt.Println("place's country; set them in the [hours] and [weekends] config tables")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz ok-to-call Kathmandu")
+	fmt.Println("  ktz config set hours.default 08:30-17:30")
+	fmt.Println("  ktz config set weekends.Nepal Sat")
+	fmt.Println("  ktz ok-to-call Berlin && page-oncall")
+}
+
+func printUntilHelp() {
+	fmt.Println("Usage: ktz until [options] <time>")
+	fmt.Println()
+	fmt.Println("Show how long it is until a wall clock time in a place, following its DST changes.")
+	fmt.Println("The date is optional: a weekday, today, tomorrow or YYYY-MM-DD; a time that has")
+	fmt.Println("passed is the next one")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -z string   Place or timezone of the time (default: the place after the time, or home)")
+	fmt.Println("  -live       Show a live countdown")
+	fmt.Println("  -seconds    Print only the remaining seconds, for scripts")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz until \"2026-12-31 23:59\" -z Asia/Kathmandu")
+	fmt.Println("  ktz until friday 17:00 London")
+	fmt.Println("  ktz until -live 9am tomorrow")
+	fmt.Println("  sleep $(ktz until -seconds 09:00 Berlin)")
+}
+
+func printHolidaysHelp() {
+	fmt.Println("Usage: ktz holidays [-year year] -c <country>")
+	fmt.Println()
+	fmt.Println("List the public holidays of a country in a year. Lookups show a Holiday column")
+	fmt.Println("and the status 'holiday' on these days. Add company holidays from .ics or .json")
+	fmt.Println("files per alpha-2 code, or for every country with 'all'")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -c string    Country name or alpha2/alpha3 code")
+	fmt.Println("  -year int    Year (default: this year)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz holidays -c NP -year 2026")
+	fmt.Println("  ktz config set holidays.NP ~/company-np.ics")
+	fmt.Println("  ktz config set holidays.all ~/company.json")
+}
+
+func printConvertHelp() {
+	fmt.Println("Usage: ktz convert [options] <time> [places...]")
+	fmt.Println()
+	fmt.Println("Convert a time like \"2026-11-03 09:30 Kathmandu\" to each place (default: home).")
+	fmt.Println("The date can also be a weekday, today or tomorrow; without a date the time is")
+	fmt.Println("today; without a place it is in the home zone")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -ics              Print an iCalendar (.ics) event with its VTIMEZONE instead")
+	fmt.Println("  -title string     Summary of the event (default \"Meeting\")")
+	fmt.Println("  -duration string  Duration of the event like 30m (default 1h)")
+	fmt.Println("  -daylight         Show whether it's day or night in each place")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz convert \"09:30 Kathmandu\" London \"New York\"")
+	fmt.Println("  ktz convert -ics -title Standup \"2026-11-03 9:30am Kathmandu\" > standup.ics")
+}
+
+func printVTimezoneHelp() {
+	fmt.Println("Usage: ktz vtimezone [-from year] <place>")
+	fmt.Println()
+	fmt.Println("Print the iCalendar VTIMEZONE component of a city, country or zone,")
+	fmt.Println("derived from its transitions and covering the times from -from on (default: this year)")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz vtimezone America/New_York")
+	fmt.Println("  ktz vtimezone -from 2000 Sydney")
 }
 
+func printPOSIXHelp() {
+	fmt.Println("Usage: ktz posix <place>")
+	fmt.Println()
+	fmt.Println("Print the POSIX TZ string of a city, country or zone, e.g. to set TZ on")
+	fmt.Println("devices without zoneinfo. POSIX TZ strings are also accepted wherever a")
+	fmt.Println("zone is, like ktz lookup -z")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz posix \"New York\"")
+	fmt.Println("  ktz posix Asia/Kathmandu")
+	fmt.Println("  ktz lookup -z=\"CET-1CEST,M3.5.0,M10.5.0/3\"")
+}
+
+func printReverseHelp() {
+	fmt.Println("Usage: ktz reverse <zone|offset>")
+	fmt.Println()
+	fmt.Println("List the countries, with their ISO codes, and the known cities using a zone")
+	fmt.Println("or the zones linked to it, or every zone at a UTC offset now. Places sharing")
+	fmt.Println("DST rules are listed together, with the rules from their POSIX TZ string")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz reverse Asia/Kolkata")
+	fmt.Println("  ktz reverse +0545")
+	fmt.Println("  ktz reverse UTC-05:00")
+}
+
+func printExploreHelp() {
+	fmt.Println("Usage: ktz explore [-home place] [-daylight]")
+	fmt.Println()
+	fmt.Println("Browse the regions, their countries, the timezones of a country and the")
+	fmt.Println("cities using a timezone, with the current time, offset, DST status and next")
+	fmt.Println("transition of the selected entry, then look up the city or timezone chosen")
+	fmt.Println()
+	fmt.Println("Keys:")
+	fmt.Println("  enter, right   Open the selected entry, or choose it at the last level")
+	fmt.Println("  esc, left      Go back a level")
+	fmt.Println("  a-z            Filter the current level")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz explore")
+	fmt.Println("  ktz explore -home London -daylight")
+}
+
+func printListHelp() {
+	fmt.Println("Usage: ktz list [options] <countries|cities|zones|abbreviations>")
+	fmt.Println()
+	fmt.Println("List the built-in countries, cities, zones or abbreviations with their UTC")
+	fmt.Println("offset now and whether they observe DST, filtered and sorted by the options")
+	fmt.Println()
+	fmt.Println("Options:")
+	fmt.Println("  -country <name|code>   Only entries of a country, like NP or Nepal")
+	fmt.Println("  -offset <offset>       Only entries at a UTC offset now, like +5:45")
+	fmt.Println("  -region <region>       Only entries with a zone in a region, like Europe")
+	fmt.Println("  -dst                   Only entries with a zone observing DST")
+	fmt.Println("  -sort <key>            Sort by name (default), offset, country or region")
+	fmt.Println("  -o <format>            Output as table, plain, json or csv")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz list countries -offset +5:45")
+	fmt.Println("  ktz list zones -region Europe -dst -sort offset")
+	fmt.Println("  ktz list cities -country US -o csv")
+}
+
+func printInspectHelp() {
+	fmt.Println("Usage: ktz inspect <file>")
+	fmt.Println()
+	fmt.Println("Show the header, local time types, transitions, leap seconds and POSIX TZ")
+	fmt.Println("footer of a TZif file, and the known zones it most likely corresponds to")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  ktz inspect /etc/localtime")
+	fmt.Println("  ktz inspect /usr/share/zoneinfo/Asia/Kathmandu")
+}
+
+func printVersionHelp() {
+	fmt.Println("Usage: ktz [--tzdata-dir <dir|zip>] version [--tzdata]")
+	fmt.Println()
+	fmt.Println("Show the ktz version and, with --tzdata, the timezone data in use.")
+	fmt.Println("Timezones are loaded from --tzdata-dir or $ZONEINFO if given, then from")
+	fmt.Println("the system's zoneinfo, then from the snapshot embedded in ktz.")
+}
 
 /////////////////////////////////////Completed///////////////////////////
 
@@ -1,178 +1,195 @@
 package tzdata
 
-var AbbToIanaTimezone = map[string]string{
-	"ACDT":  "Australia/Adelaide",
-	"ACST":  "Australia/Darwin",
-	"ACT":   "America/Argentina/Buenos_Aires",
-	"ADT":   "America/Halifax",
-	"AEDT":  "Australia/Sydney",
-	"AEST":  "Australia/Brisbane",
-	"AFT":   "Asia/Kabul",
-	"AKDT":  "America/Juneau",
-	"AKST":  "America/Juneau",
-	"AMST":  "America/Campo_Grande",
-	"AMT":   "America/Campo_Grande",
-	"ART":   "America/Argentina/Buenos_Aires",
-	"AST":   "America/Halifax",
-	"AWDT":  "Australia/Perth",
-	"AWST":  "Australia/Perth",
-	"AZOST": "Atlantic/Azores",
-	"AZOT":  "Atlantic/Azores",
-	"AZT":   "Asia/Baku",
-	"BDT":   "Asia/Dhaka",
-	"BIOT":  "Indian/Chagos",
-	"BRT":   "America/Sao_Paulo",
-	"BST":   "Europe/London",
-	"BTT":   "Asia/Thimphu",
-	"CAT":   "Africa/Harare",
-	"CCT":   "Indian/Cocos",
-	"CDT":   "America/Chicago",
-	"CEST":  "Europe/Berlin",
-	"CET":   "Europe/Berlin",
-	"CHAST": "Pacific/Chatham",
-	"CHOT":  "Asia/Choibalsan",
-	"CHST":  "Pacific/Guam",
-	"CHUT":  "Pacific/Chuuk",
-	"CIST":  "Pacific/Rarotonga",
-	"CIT":   "Asia/Makassar",
-	"CKT":   "Pacific/Rarotonga",
-	"CLST":  "America/Santiago",
-	"CLT":   "America/Santiago",
-	"COST":  "America/Bogota",
-	"COT":   "America/Bogota",
-	"CST":   "America/Chicago",
-	"CT":    "America/Chicago",
-	"CVT":   "Atlantic/Cape_Verde",
-	"CXT":   "Indian/Christmas",
-	"DAVT":  "Antarctica/Davis",
-	"DDUT":  "Antarctica/DumontDUrville",
-	"DFT":   "Europe/Paris",
-	"EASST": "Pacific/Easter",
-	"EAST":  "Pacific/Easter",
-	"EAT":   "Africa/Nairobi",
-	"ECT":   "Europe/Paris",
-	"EDT":   "America/New_York",
-	"EEST":  "Europe/Istanbul",
-	"EET":   "Europe/Istanbul",
-	"EGST":  "America/Scoresbysund",
-	"EGT":   "America/Scoresbysund",
-	"EST":   "America/New_York",
-	"ET":    "America/New_York",
-	"FET":   "Europe/Kaliningrad",
-	"FJT":   "Pacific/Fiji",
-	"FKST":  "Atlantic/Stanley",
-	"FKT":   "Atlantic/Stanley",
-	"FNT":   "America/Noronha",
-	"GALT":  "Pacific/Galapagos",
-	"GAMT":  "Pacific/Gambier",
-	"GET":   "Asia/Tbilisi",
-	"GFT":   "America/Cayenne",
-	"GILT":  "Pacific/Tarawa",
-	"GIT":   "Pacific/Gambier",
-	"GMT":   "Etc/Greenwich",
-	"GST":   "Asia/Dubai",
-	"GYT":   "America/Guyana",
-	"HDT":   "Pacific/Honolulu",
-	"HAEC":  "Europe/Paris",
-	"HST":   "Pacific/Honolulu",
-	"HKT":   "Asia/Hong_Kong",
-	"HOVT":  "Asia/Hovd",
-	"ICT":   "Asia/Bangkok",
-	"IDLW":  "Etc/GMT+12",
-	"IDT":   "Asia/Jerusalem",
-	"IOT":   "Indian/Chagos",
-	"IRDT":  "Asia/Tehran",
-	"IRKT":  "Asia/Irkutsk",
-	"IRST":  "Asia/Tehran",
-	"IST":   "Asia/Kolkata",
-	"JST":   "Asia/Tokyo",
-	"KALT":  "Europe/Kaliningrad",
-	"KGT":   "Asia/Bishkek",
-	"KOST":  "Pacific/Kosrae",
-	"KRAT":  "Asia/Krasnoyarsk",
-	"KST":   "Asia/Seoul",
-	"LHST":  "Australia/Lord_Howe",
-	"LINT":  "Pacific/Kiritimati",
-	"MAGT":  "Asia/Magadan",
-	"MART":  "Pacific/Marquesas",
-	"MAWT":  "Antarctica/Mawson",
-	"MDT":   "America/Denver",
-	"MET":   "Europe/Paris",
-	"MEST":  "Europe/Paris",
-	"MHT":   "Pacific/Kwajalein",
-	"MIST":  "Antarctica/Macquarie",
-	"MIT":   "Pacific/Apia",
-	"MMT":   "Asia/Yangon",
-	"MSK":   "Europe/Moscow",
-	"MST":   "America/Denver",
-	"MUT":   "Indian/Mauritius",
-	"MVT":   "Indian/Maldives",
-	"MYT":   "Asia/Kuala_Lumpur",
-	"NCT":   "Pacific/Noumea",
-	"NDT":   "America/St_Johns",
-	"NFT":   "Pacific/Norfolk",
-	"NPT":   "Asia/Kathmandu",
-	"NST":   "America/St_Johns",
-	"NT":    "America/St_Johns",
-	"NUT":   "Pacific/Niue",
-	"NZDT":  "Pacific/Auckland",
-	"NZST":  "Pacific/Auckland",
-	"OMST":  "Asia/Omsk",
-	"ORAT":  "Asia/Oral",
-	"PDT":   "America/Los_Angeles",
-	"PET":   "America/Lima",
-	"PETT":  "Asia/Kamchatka",
-	"PGT":   "Pacific/Port_Moresby",
-	"PHOT":  "Pacific/Enderbury",
-	"PHT":   "Asia/Manila",
-	"PKT":   "Asia/Karachi",
-	"PMDT":  "America/Miquelon",
-	"PMST":  "America/Miquelon",
-	"PONT":  "Pacific/Pohnpei",
-	"PST":   "America/Los_Angeles",
-	"PWT":   "Pacific/Palau",
-	"PYST":  "America/Asuncion",
-	"PYT":   "America/Asuncion",
-	"RET":   "Indian/Reunion",
-	"ROTT":  "Antarctica/Rothera",
-	"SAKT":  "Asia/Sakhalin",
-	"SAMT":  "Europe/Samara",
-	"SAST":  "Africa/Johannesburg",
-	"SBT":   "Pacific/Guadalcanal",
-	"SCT":   "Indian/Mahe",
-	"SGT":   "Asia/Singapore",
-	"SLST":  "Asia/Colombo",
-	"SRET":  "Asia/Srednekolymsk",
-	"SRT":   "America/Paramaribo",
-	"SST":   "Pacific/Pago_Pago",
-	"SYOT":  "Antarctica/Syowa",
-	"TAHT":  "Pacific/Tahiti",
-	"THA":   "Asia/Bangkok",
-	"TFT":   "Indian/Kerguelen",
-	"TJT":   "Asia/Dushanbe",
-	"TKT":   "Pacific/Fakaofo",
-	"TLT":   "Asia/Dili",
-	"TMT":   "Asia/Ashgabat",
-	"TRT":   "Europe/Istanbul",
-	"TOT":   "Pacific/Tongatapu",
-	"TVT":   "Pacific/Funafuti",
-	"ULAT":  "Asia/Ulaanbaatar",
-	"UTC":   "",
-	"UYST":  "America/Montevideo",
-	"UYT":   "America/Montevideo",
-	"UZT":   "Asia/Tashkent",
-	"VET":   "America/Caracas",
-	"VLAT":  "Asia/Vladivostok",
-	"VOLT":  "Europe/Volgograd",
-	"VOST":  "Antarctica/Vostok",
-	"VUT":   "Pacific/Efate",
-	"WAKT":  "Pacific/Wake",
-	"WAST":  "Africa/Windhoek",
-	"WAT":   "Africa/Lagos",
-	"WEDT":  "Europe/Lisbon",
-	"WEST":  "Europe/Lisbon",
-	"WET":   "Europe/Lisbon",
-	"WST":   "Australia/Perth",
-	"YAKT":  "Asia/Yakutsk",
-	"YEKT":  "Asia/Yekaterinburg",
+// hour and minute are in seconds, the unit of the offsets.
+const (
+	hour   = 60 * minute
+	minute = 60
+)
+
+// Abbreviation is the meaning of a zone abbreviation like PST: the timezone using it,
+// its fixed UTC offset and whether it names daylight saving time (PDT) or standard time (PST).
+// A generic abbreviation like ET names the local time of the zone, whichever is in effect.
+type Abbreviation struct {
+	Zone    string // The IANA timezone using the abbreviation, or "" for UTC
+	Offset  int    // Seconds east of UTC, like the offset of time.Time.Zone()
+	DST     bool   // Whether the abbreviation names daylight saving time
+	Generic bool   // Whether the abbreviation follows the daylight saving time of the zone
+}
+
+// AbbToIanaTimezone maps zone abbreviations to their meaning.
+var AbbToIanaTimezone = map[string]Abbreviation{
+	"ACDT":  {Zone: "Australia/Adelaide", Offset: 10*hour + 30*minute, DST: true},
+	"ACST":  {Zone: "Australia/Darwin", Offset: 9*hour + 30*minute},
+	"ACT":   {Zone: "America/Argentina/Buenos_Aires", Offset: -3 * hour},
+	"ADT":   {Zone: "America/Halifax", Offset: -3 * hour, DST: true},
+	"AEDT":  {Zone: "Australia/Sydney", Offset: 11 * hour, DST: true},
+	"AEST":  {Zone: "Australia/Brisbane", Offset: 10 * hour},
+	"AFT":   {Zone: "Asia/Kabul", Offset: 4*hour + 30*minute},
+	"AKDT":  {Zone: "America/Juneau", Offset: -8 * hour, DST: true},
+	"AKST":  {Zone: "America/Juneau", Offset: -9 * hour},
+	"AMST":  {Zone: "America/Campo_Grande", Offset: -3 * hour, DST: true},
+	"AMT":   {Zone: "America/Campo_Grande", Offset: -4 * hour},
+	"ART":   {Zone: "America/Argentina/Buenos_Aires", Offset: -3 * hour},
+	"AST":   {Zone: "America/Halifax", Offset: -4 * hour},
+	"AWDT":  {Zone: "Australia/Perth", Offset: 9 * hour, DST: true},
+	"AWST":  {Zone: "Australia/Perth", Offset: 8 * hour},
+	"AZOST": {Zone: "Atlantic/Azores", DST: true},
+	"AZOT":  {Zone: "Atlantic/Azores", Offset: -1 * hour},
+	"AZT":   {Zone: "Asia/Baku", Offset: 4 * hour},
+	"BDT":   {Zone: "Asia/Dhaka", Offset: 6 * hour},
+	"BIOT":  {Zone: "Indian/Chagos", Offset: 6 * hour},
+	"BRT":   {Zone: "America/Sao_Paulo", Offset: -3 * hour},
+	"BST":   {Zone: "Europe/London", Offset: 1 * hour, DST: true},
+	"BTT":   {Zone: "Asia/Thimphu", Offset: 6 * hour},
+	"CAT":   {Zone: "Africa/Harare", Offset: 2 * hour},
+	"CCT":   {Zone: "Indian/Cocos", Offset: 6*hour + 30*minute},
+	"CDT":   {Zone: "America/Chicago", Offset: -5 * hour, DST: true},
+	"CEST":  {Zone: "Europe/Berlin", Offset: 2 * hour, DST: true},
+	"CET":   {Zone: "Europe/Berlin", Offset: 1 * hour},
+	"CHAST": {Zone: "Pacific/Chatham", Offset: 12*hour + 45*minute},
+	"CHOT":  {Zone: "Asia/Choibalsan", Offset: 8 * hour},
+	"CHST":  {Zone: "Pacific/Guam", Offset: 10 * hour},
+	"CHUT":  {Zone: "Pacific/Chuuk", Offset: 10 * hour},
+	"CIST":  {Zone: "Pacific/Rarotonga", Offset: -10 * hour},
+	"CIT":   {Zone: "Asia/Makassar", Offset: 8 * hour},
+	"CKT":   {Zone: "Pacific/Rarotonga", Offset: -10 * hour},
+	"CLST":  {Zone: "America/Santiago", Offset: -3 * hour, DST: true},
+	"CLT":   {Zone: "America/Santiago", Offset: -4 * hour},
+	"COST":  {Zone: "America/Bogota", Offset: -4 * hour, DST: true},
+	"COT":   {Zone: "America/Bogota", Offset: -5 * hour},
+	"CST":   {Zone: "America/Chicago", Offset: -6 * hour},
+	"CT":    {Zone: "America/Chicago", Offset: -6 * hour, Generic: true},
+	"CVT":   {Zone: "Atlantic/Cape_Verde", Offset: -1 * hour},
+	"CXT":   {Zone: "Indian/Christmas", Offset: 7 * hour},
+	"DAVT":  {Zone: "Antarctica/Davis", Offset: 7 * hour},
+	"DDUT":  {Zone: "Antarctica/DumontDUrville", Offset: 10 * hour},
+	"DFT":   {Zone: "Europe/Paris", Offset: 1 * hour},
+	"EASST": {Zone: "Pacific/Easter", Offset: -5 * hour, DST: true},
+	"EAST":  {Zone: "Pacific/Easter", Offset: -6 * hour},
+	"EAT":   {Zone: "Africa/Nairobi", Offset: 3 * hour},
+	"ECT":   {Zone: "Europe/Paris", Offset: 1 * hour},
+	"EDT":   {Zone: "America/New_York", Offset: -4 * hour, DST: true},
+	"EEST":  {Zone: "Europe/Istanbul", Offset: 3 * hour, DST: true},
+	"EET":   {Zone: "Europe/Istanbul", Offset: 2 * hour},
+	"EGST":  {Zone: "America/Scoresbysund", DST: true},
+	"EGT":   {Zone: "America/Scoresbysund", Offset: -1 * hour},
+	"EST":   {Zone: "America/New_York", Offset: -5 * hour},
+	"ET":    {Zone: "America/New_York", Offset: -5 * hour, Generic: true},
+	"FET":   {Zone: "Europe/Kaliningrad", Offset: 3 * hour},
+	"FJT":   {Zone: "Pacific/Fiji", Offset: 12 * hour},
+	"FKST":  {Zone: "Atlantic/Stanley", Offset: -3 * hour, DST: true},
+	"FKT":   {Zone: "Atlantic/Stanley", Offset: -4 * hour},
+	"FNT":   {Zone: "America/Noronha", Offset: -2 * hour},
+	"GALT":  {Zone: "Pacific/Galapagos", Offset: -6 * hour},
+	"GAMT":  {Zone: "Pacific/Gambier", Offset: -9 * hour},
+	"GET":   {Zone: "Asia/Tbilisi", Offset: 4 * hour},
+	"GFT":   {Zone: "America/Cayenne", Offset: -3 * hour},
+	"GILT":  {Zone: "Pacific/Tarawa", Offset: 12 * hour},
+	"GIT":   {Zone: "Pacific/Gambier", Offset: -9 * hour},
+	"GMT":   {Zone: "Etc/Greenwich"},
+	"GST":   {Zone: "Asia/Dubai", Offset: 4 * hour},
+	"GYT":   {Zone: "America/Guyana", Offset: -4 * hour},
+	"HDT":   {Zone: "Pacific/Honolulu", Offset: -9 * hour, DST: true},
+	"HAEC":  {Zone: "Europe/Paris", Offset: 2 * hour, DST: true},
+	"HST":   {Zone: "Pacific/Honolulu", Offset: -10 * hour},
+	"HKT":   {Zone: "Asia/Hong_Kong", Offset: 8 * hour},
+	"HOVT":  {Zone: "Asia/Hovd", Offset: 7 * hour},
+	"ICT":   {Zone: "Asia/Bangkok", Offset: 7 * hour},
+	"IDLW":  {Zone: "Etc/GMT+12", Offset: -12 * hour},
+	"IDT":   {Zone: "Asia/Jerusalem", Offset: 3 * hour, DST: true},
+	"IOT":   {Zone: "Indian/Chagos", Offset: 6 * hour},
+	"IRDT":  {Zone: "Asia/Tehran", Offset: 4*hour + 30*minute, DST: true},
+	"IRKT":  {Zone: "Asia/Irkutsk", Offset: 8 * hour},
+	"IRST":  {Zone: "Asia/Tehran", Offset: 3*hour + 30*minute},
+	"IST":   {Zone: "Asia/Kolkata", Offset: 5*hour + 30*minute},
+	"JST":   {Zone: "Asia/Tokyo", Offset: 9 * hour},
+	"KALT":  {Zone: "Europe/Kaliningrad", Offset: 2 * hour},
+	"KGT":   {Zone: "Asia/Bishkek", Offset: 6 * hour},
+	"KOST":  {Zone: "Pacific/Kosrae", Offset: 11 * hour},
+	"KRAT":  {Zone: "Asia/Krasnoyarsk", Offset: 7 * hour},
+	"KST":   {Zone: "Asia/Seoul", Offset: 9 * hour},
+	"LHST":  {Zone: "Australia/Lord_Howe", Offset: 10*hour + 30*minute},
+	"LINT":  {Zone: "Pacific/Kiritimati", Offset: 14 * hour},
+	"MAGT":  {Zone: "Asia/Magadan", Offset: 11 * hour},
+	"MART":  {Zone: "Pacific/Marquesas", Offset: -(9*hour + 30*minute)},
+	"MAWT":  {Zone: "Antarctica/Mawson", Offset: 5 * hour},
+	"MDT":   {Zone: "America/Denver", Offset: -6 * hour, DST: true},
+	"MET":   {Zone: "Europe/Paris", Offset: 1 * hour},
+	"MEST":  {Zone: "Europe/Paris", Offset: 2 * hour, DST: true},
+	"MHT":   {Zone: "Pacific/Kwajalein", Offset: 12 * hour},
+	"MIST":  {Zone: "Antarctica/Macquarie", Offset: 11 * hour},
+	"MIT":   {Zone: "Pacific/Apia", Offset: 13 * hour},
+	"MMT":   {Zone: "Asia/Yangon", Offset: 6*hour + 30*minute},
+	"MSK":   {Zone: "Europe/Moscow", Offset: 3 * hour},
+	"MST":   {Zone: "America/Denver", Offset: -7 * hour},
+	"MUT":   {Zone: "Indian/Mauritius", Offset: 4 * hour},
+	"MVT":   {Zone: "Indian/Maldives", Offset: 5 * hour},
+	"MYT":   {Zone: "Asia/Kuala_Lumpur", Offset: 8 * hour},
+	"NCT":   {Zone: "Pacific/Noumea", Offset: 11 * hour},
+	"NDT":   {Zone: "America/St_Johns", Offset: -(2*hour + 30*minute), DST: true},
+	"NFT":   {Zone: "Pacific/Norfolk", Offset: 11 * hour},
+	"NPT":   {Zone: "Asia/Kathmandu", Offset: 5*hour + 45*minute},
+	"NST":   {Zone: "America/St_Johns", Offset: -(3*hour + 30*minute)},
+	"NT":    {Zone: "America/St_Johns", Offset: -(3*hour + 30*minute), Generic: true},
+	"NUT":   {Zone: "Pacific/Niue", Offset: -11 * hour},
+	"NZDT":  {Zone: "Pacific/Auckland", Offset: 13 * hour, DST: true},
+	"NZST":  {Zone: "Pacific/Auckland", Offset: 12 * hour},
+	"OMST":  {Zone: "Asia/Omsk", Offset: 6 * hour},
+	"ORAT":  {Zone: "Asia/Oral", Offset: 5 * hour},
+	"PDT":   {Zone: "America/Los_Angeles", Offset: -7 * hour, DST: true},
+	"PET":   {Zone: "America/Lima", Offset: -5 * hour},
+	"PETT":  {Zone: "Asia/Kamchatka", Offset: 12 * hour},
+	"PGT":   {Zone: "Pacific/Port_Moresby", Offset: 10 * hour},
+	"PHOT":  {Zone: "Pacific/Enderbury", Offset: 13 * hour},
+	"PHT":   {Zone: "Asia/Manila", Offset: 8 * hour},
+	"PKT":   {Zone: "Asia/Karachi", Offset: 5 * hour},
+	"PMDT":  {Zone: "America/Miquelon", Offset: -2 * hour, DST: true},
+	"PMST":  {Zone: "America/Miquelon", Offset: -3 * hour},
+	"PONT":  {Zone: "Pacific/Pohnpei", Offset: 11 * hour},
+	"PST":   {Zone: "America/Los_Angeles", Offset: -8 * hour},
+	"PWT":   {Zone: "Pacific/Palau", Offset: 9 * hour},
+	"PYST":  {Zone: "America/Asuncion", Offset: -3 * hour, DST: true},
+	"PYT":   {Zone: "America/Asuncion", Offset: -4 * hour},
+	"RET":   {Zone: "Indian/Reunion", Offset: 4 * hour},
+	"ROTT":  {Zone: "Antarctica/Rothera", Offset: -3 * hour},
+	"SAKT":  {Zone: "Asia/Sakhalin", Offset: 11 * hour},
+	"SAMT":  {Zone: "Europe/Samara", Offset: 4 * hour},
+	"SAST":  {Zone: "Africa/Johannesburg", Offset: 2 * hour},
+	"SBT":   {Zone: "Pacific/Guadalcanal", Offset: 11 * hour},
+	"SCT":   {Zone: "Indian/Mahe", Offset: 4 * hour},
+	"SGT":   {Zone: "Asia/Singapore", Offset: 8 * hour},
+	"SLST":  {Zone: "Asia/Colombo", Offset: 5*hour + 30*minute},
+	"SRET":  {Zone: "Asia/Srednekolymsk", Offset: 11 * hour},
+	"SRT":   {Zone: "America/Paramaribo", Offset: -3 * hour},
+	"SST":   {Zone: "Pacific/Pago_Pago", Offset: -11 * hour},
+	"SYOT":  {Zone: "Antarctica/Syowa", Offset: 3 * hour},
+	"TAHT":  {Zone: "Pacific/Tahiti", Offset: -10 * hour},
+	"THA":   {Zone: "Asia/Bangkok", Offset: 7 * hour},
+	"TFT":   {Zone: "Indian/Kerguelen", Offset: 5 * hour},
+	"TJT":   {Zone: "Asia/Dushanbe", Offset: 5 * hour},
+	"TKT":   {Zone: "Pacific/Fakaofo", Offset: 13 * hour},
+	"TLT":   {Zone: "Asia/Dili", Offset: 9 * hour},
+	"TMT":   {Zone: "Asia/Ashgabat", Offset: 5 * hour},
+	"TRT":   {Zone: "Europe/Istanbul", Offset: 3 * hour},
+	"TOT":   {Zone: "Pacific/Tongatapu", Offset: 13 * hour},
+	"TVT":   {Zone: "Pacific/Funafuti", Offset: 12 * hour},
+	"ULAT":  {Zone: "Asia/Ulaanbaatar", Offset: 8 * hour},
+	"UTC":   {Zone: ""},
+	"UYST":  {Zone: "America/Montevideo", Offset: -2 * hour, DST: true},
+	"UYT":   {Zone: "America/Montevideo", Offset: -3 * hour},
+	"UZT":   {Zone: "Asia/Tashkent", Offset: 5 * hour},
+	"VET":   {Zone: "America/Caracas", Offset: -4 * hour},
+	"VLAT":  {Zone: "Asia/Vladivostok", Offset: 10 * hour},
+	"VOLT":  {Zone: "Europe/Volgograd", Offset: 3 * hour},
+	"VOST":  {Zone: "Antarctica/Vostok", Offset: 6 * hour},
+	"VUT":   {Zone: "Pacific/Efate", Offset: 11 * hour},
+	"WAKT":  {Zone: "Pacific/Wake", Offset: 12 * hour},
+	"WAST":  {Zone: "Africa/Windhoek", Offset: 2 * hour, DST: true},
+	"WAT":   {Zone: "Africa/Lagos", Offset: 1 * hour},
+	"WEDT":  {Zone: "Europe/Lisbon", Offset: 1 * hour, DST: true},
+	"WEST":  {Zone: "Europe/Lisbon", Offset: 1 * hour, DST: true},
+	"WET":   {Zone: "Europe/Lisbon"},
+	"WST":   {Zone: "Australia/Perth", Offset: 8 * hour},
+	"YAKT":  {Zone: "Asia/Yakutsk", Offset: 9 * hour},
+	"YEKT":  {Zone: "Asia/Yekaterinburg", Offset: 5 * hour},
 }
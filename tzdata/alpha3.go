@@ -63,7 +63,7 @@ var Alpha3ToCountry = map[string]string{
 	"DNK": "Denmark",
 	"DJI": "Djibouti",
 	"DMA": "Dominica",
-	"DOM": "Dominican Republic ",
+	"DOM": "Dominican Republic",
 	"ECU": "Ecuador",
 	"EGY": "Egypt",
 	"SLV": "El Salvador",
@@ -73,7 +73,7 @@ var Alpha3ToCountry = map[string]string{
 	"SWZ": "Eswatini",
 	"ETH": "Ethiopia",
 	"FLK": "Falkland Islands",
-	"FRO": "Faroe Islands ",
+	"FRO": "Faroe Islands",
 	"FJI": "Fiji",
 	"FIN": "Finland",
 	"FRA": "France",
@@ -81,7 +81,7 @@ var Alpha3ToCountry = map[string]string{
 	"PYF": "French Polynesia",
 	"ATF": "French Southern and Antarctic Lands",
 	"GAB": "Gabon",
-	"GMB": "Gambia ",
+	"GMB": "Gambia",
 	"GEO": "Georgia",
 	"DEU": "Germany",
 	"GHA": "Ghana",
@@ -122,7 +122,7 @@ var Alpha3ToCountry = map[string]string{
 	"KOR": "South Korea",
 	"KWT": "Kuwait",
 	"KGZ": "Kyrgyzstan",
-	"LAO": "Lao People's Democratic Republic ",
+	"LAO": "Lao People's Democratic Republic",
 	"LVA": "Latvia",
 	"LBN": "Lebanon",
 	"LSO": "Lesotho",
@@ -156,11 +156,11 @@ var Alpha3ToCountry = map[string]string{
 	"NAM": "Namibia",
 	"NRU": "Nauru",
 	"NPL": "Nepal",
-	"NLD": "Netherlands ",
+	"NLD": "Netherlands",
 	"NCL": "New Caledonia",
 	"NZL": "New Zealand",
 	"NIC": "Nicaragua",
-	"NER": "Niger ",
+	"NER": "Niger",
 	"NGA": "Nigeria",
 	"NIU": "Niue",
 	"NFK": "Norfolk Island",
@@ -174,7 +174,7 @@ var Alpha3ToCountry = map[string]string{
 	"PNG": "Papua New Guinea",
 	"PRY": "Paraguay",
 	"PER": "Peru",
-	"PHL": "Philippines ",
+	"PHL": "Philippines",
 	"PCN": "Pitcairn",
 	"POL": "Poland",
 	"PRT": "Portugal",
@@ -182,7 +182,7 @@ var Alpha3ToCountry = map[string]string{
 	"QAT": "Qatar",
 	"MKD": "Republic of North Macedonia",
 	"ROU": "Romania",
-	"RUS": "Russian Federation ",
+	"RUS": "Russian Federation",
 	"RWA": "Rwanda",
 	"REU": "Reunion",
 	"BLM": "Saint Barthelemy",
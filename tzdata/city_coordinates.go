@@ -0,0 +1,331 @@
+package tzdata
+
+// Coordinates is a position in decimal degrees, north and east being positive.
+type Coordinates struct {
+	Latitude, Longitude float64
+}
+
+// CityCoordinates maps the cities of CityToIanaTimezone to their coordinates, taken from
+// the principal location of their timezone in the zone.tab file of the IANA tzdata.
+var CityCoordinates = map[string]Coordinates{
+	"Niue":              {-19.0167, -169.9167},
+	"Midway":            {28.2167, -177.3667},
+	"Pago Pago":         {-14.2667, -170.7},
+	"Rarotonga":         {-21.2333, -159.7667},
+	"Adak":              {51.88, -176.6581},
+	"Honolulu":          {21.3069, -157.8583},
+	"Tahiti":            {-17.5333, -149.5667},
+	"Taiohae":           {-9, -139.5},
+	"Anchorage":         {61.2181, -149.9003},
+	"Rikitea":           {-23.1333, -134.95},
+	"Los Angeles":       {34.0522, -118.2428},
+	"Tijuana":           {32.5333, -117.0167},
+	"Vancouver":         {49.2667, -123.1167},
+	"Adamstown":         {-25.0667, -130.0833},
+	"Hermosillo":        {29.0667, -110.9667},
+	"Edmonton":          {53.55, -113.4667},
+	"Ciudad Juarez":     {31.7333, -106.4833},
+	"Denver":            {39.7392, -104.9842},
+	"Phoenix":           {33.4483, -112.0733},
+	"Whitehorse":        {60.7167, -135.05},
+	"Belize":            {17.5, -88.2},
+	"Chicago":           {41.85, -87.65},
+	"Guatemala":         {14.6333, -90.5167},
+	"Managua":           {12.15, -86.2833},
+	"Mexico City":       {19.4, -99.15},
+	"Matamoros":         {25.8333, -97.5},
+	"Costa Rica":        {9.9333, -84.0833},
+	"El Salvador":       {13.7, -89.2},
+	"Regina":            {50.4, -104.65},
+	"Tegucigalpa":       {14.1, -87.2167},
+	"Winnipeg":          {49.8833, -97.15},
+	"Easter Island":     {-27.15, -109.4333},
+	"Galapagos":         {-0.9, -89.6},
+	"Rio Branco":        {-9.9667, -67.8},
+	"Bogota":            {4.6, -74.0833},
+	"Havana":            {23.1333, -82.3667},
+	"Atikokan":          {48.7586, -91.6217},
+	"Cancun":            {21.0833, -86.7667},
+	"Cockburn Town":     {21.4667, -71.1333},
+	"George Town":       {19.3, -81.3833},
+	"Jamaica":           {17.9681, -76.7933},
+	"Nassau":            {25.0833, -77.35},
+	"New York":          {40.7142, -74.0064},
+	"Panama":            {8.9667, -79.5333},
+	"Port-au-Prince":    {18.5333, -72.3333},
+	"Toronto":           {43.65, -79.3833},
+	"Guayaquil":         {-2.1667, -79.8333},
+	"Lima":              {-12.05, -77.05},
+	"Manaus":            {-3.1333, -60.0167},
+	"Basseterre":        {17.3, -62.7167},
+	"Blanc-Sablon":      {51.4167, -57.1167},
+	"Plymouth":          {16.7167, -62.2167},
+	"Barbados":          {13.1, -59.6167},
+	"Port of Spain":     {10.65, -61.5167},
+	"Martinique":        {14.6, -61.0833},
+	"Castries":          {14.0167, -61},
+	"Gustavia":          {17.8833, -62.85},
+	"Halifax":           {44.65, -63.6},
+	"Hamilton":          {32.2833, -64.7667},
+	"Kingstown":         {13.15, -61.2333},
+	"Kralendijk":        {12.1508, -68.2767},
+	"Basse-Terre":       {16.2333, -61.5333},
+	"Marigot":           {18.0667, -63.0833},
+	"Oranjestad":        {12.5, -69.9667},
+	"Lower Princes":     {18.0514, -63.0472},
+	"Tortola":           {18.45, -64.6167},
+	"Roseau":            {15.3, -61.4},
+	"Charlotte Amalie":  {18.35, -64.9333},
+	"Road Town":         {18.45, -64.6167},
+	"St Georges":        {12.05, -61.75},
+	"Antigua":           {17.05, -61.8},
+	"Puerto Rico":       {18.4683, -66.1061},
+	"Santo Domingo":     {18.4667, -69.9},
+	"The Valley":        {18.2, -63.0667},
+	"Thule":             {76.5667, -68.7833},
+	"Willemstad":        {12.1833, -69},
+	"La Paz":            {-16.5, -68.15},
+	"Santiago":          {-33.45, -70.6667},
+	"Guyana":            {6.8, -58.1667},
+	"Asuncion":          {-25.2667, -57.6667},
+	"Caracas":           {10.5, -66.9333},
+	"St Johns":          {47.5667, -52.7167},
+	"Buenos Aires":      {-34.6, -58.45},
+	"Sao Paulo":         {-23.5333, -46.6167},
+	"Palmer":            {-64.8, -64.1},
+	"Punta Arenas":      {-53.15, -70.9167},
+	"Stanley":           {-51.7, -57.85},
+	"Cayenne":           {4.9333, -52.3333},
+	"Miquelon":          {47.05, -56.3333},
+	"Paramaribo":        {5.8333, -55.1667},
+	"Montevideo":        {-34.9092, -56.2125},
+	"Ittoqqortoormiit":  {70.4833, -21.9667},
+	"Noronha":           {-3.85, -32.4167},
+	"King Edward Point": {-54.2667, -36.5333},
+	"Nuuk":              {64.1833, -51.7333},
+	"Azores":            {37.7333, -25.6667},
+	"Cape_Verde":        {14.9167, -23.5167},
+	"Abidjan":           {5.3167, -4.0333},
+	"Bamako":            {12.65, -8},
+	"Bissau":            {11.85, -15.5833},
+	"Conakry":           {9.5167, -13.7167},
+	"Dakar":             {14.6667, -17.4333},
+	"Danmarkshavn":      {76.7667, -18.6667},
+	"Douglas":           {54.15, -4.4667},
+	"Dublin":            {53.3333, -6.25},
+	"Freetown":          {8.5, -13.25},
+	"Jamestown":         {-15.9167, -5.7},
+	"Accra":             {5.55, -0.2167},
+	"Lome":              {6.1333, 1.2167},
+	"London":            {51.5083, -0.1253},
+	"Monrovia":          {6.3, -10.7833},
+	"Nouakchott":        {18.1, -15.95},
+	"Ouagadougou":       {12.3667, -1.5167},
+	"Reykjavik":         {64.15, -21.85},
+	"St Helier":         {49.1836, -2.1067},
+	"St Peter Port":     {49.4547, -2.5361},
+	"Banjul":            {13.4667, -16.65},
+	"Sao Tome":          {0.3333, 6.7333},
+	"Troll":             {-72.0114, 2.535},
+	"Casablanca":        {33.65, -7.5833},
+	"El Aaiun":          {27.15, -13.2},
+	"Canary":            {28.1, -15.4},
+	"Lisbon":            {38.7167, -9.1333},
+	"Torshavn":          {62.0167, -6.7667},
+	"Windhoek":          {-22.5667, 17.1},
+	"Algiers":           {36.7833, 3.05},
+	"Amsterdam":         {52.3667, 4.9},
+	"Andorra la Vella":  {42.5, 1.5167},
+	"Belgrade":          {44.8333, 20.5},
+	"Berlin":            {52.5, 13.3667},
+	"Bratislava":        {48.15, 17.1167},
+	"Brussels":          {50.8333, 4.3333},
+	"Budapest":          {47.5, 19.0833},
+	"Copenhagen":        {55.6667, 12.5833},
+	"Gibraltar":         {36.1333, -5.35},
+	"Ljubljana":         {46.05, 14.5167},
+	"Longyearbyen":      {78, 16},
+	"Luxembourg":        {49.6, 6.15},
+	"Madrid":            {40.4, -3.6833},
+	"Monaco":            {43.7, 7.3833},
+	"Oslo":              {59.9167, 10.75},
+	"Paris":             {48.8667, 2.3333},
+	"Podgorica":         {42.4333, 19.2667},
+	"Prague":            {50.0833, 14.4333},
+	"Rome":              {41.9, 12.4833},
+	"San Marino":        {43.9167, 12.4667},
+	"Malta":             {35.9, 14.5167},
+	"Sarajevo":          {43.8667, 18.4167},
+	"Skopje":            {41.9833, 21.4333},
+	"Stockholm":         {59.3333, 18.05},
+	"Tirane":            {41.3333, 19.8333},
+	"Tunis":             {36.8, 10.1833},
+	"Vaduz":             {47.15, 9.5167},
+	"Vatican":           {41.9022, 12.4531},
+	"Vienna":            {48.2167, 16.3333},
+	"Warsaw":            {52.25, 21},
+	"Zagreb":            {45.8, 15.9667},
+	"Zurich":            {47.3833, 8.5333},
+	"Bangui":            {4.3667, 18.5833},
+	"Malabo":            {3.75, 8.7833},
+	"Brazzaville":       {-4.2667, 15.2833},
+	"Porto-Novo":        {6.4833, 2.6167},
+	"Douala":            {4.05, 9.7},
+	"Kinshasa":          {-4.3, 15.3},
+	"Lagos":             {6.45, 3.4},
+	"Libreville":        {0.3833, 9.45},
+	"Luanda":            {-8.8, 13.2333},
+	"Ndjamena":          {12.1167, 15.05},
+	"Niamey":            {13.5167, 2.1167},
+	"Bujumbura":         {-3.3833, 29.3667},
+	"Gaborone":          {-24.65, 25.9167},
+	"Harare":            {-17.8333, 31.05},
+	"Juba":              {4.85, 31.6167},
+	"Khartoum":          {15.6, 32.5333},
+	"Kigali":            {-1.95, 30.0667},
+	"Blantyre":          {-15.7833, 35},
+	"Lubumbashi":        {-11.6667, 27.4667},
+	"Lusaka":            {-15.4167, 28.2833},
+	"Maputo":            {-25.9667, 32.5833},
+	"Athens":            {37.9667, 23.7167},
+	"Beirut":            {33.8833, 35.5},
+	"Bucharest":         {44.4333, 26.1},
+	"Cairo":             {30.05, 31.25},
+	"Chisinau":          {47, 28.8333},
+	"Hebron":            {31.5333, 35.095},
+	"Helsinki":          {60.1667, 24.9667},
+	"Kaliningrad":       {54.7167, 20.5},
+	"Kyiv":              {50.4333, 30.5167},
+	"Mariehamn":         {60.1, 19.95},
+	"Nicosia":           {35.1667, 33.3667},
+	"Riga":              {56.95, 24.1},
+	"Sofia":             {42.6833, 23.3167},
+	"Tallinn":           {59.4167, 24.75},
+	"Tripoli":           {32.9, 13.1833},
+	"Vilnius":           {54.6833, 25.3167},
+	"Jerusalem":         {31.7806, 35.2239},
+	"Johannesburg":      {-26.25, 28},
+	"Mbabane":           {-26.3, 31.1},
+	"Maseru":            {-29.4667, 27.5},
+	"Kuwait":            {29.3333, 47.9833},
+	"Bahrain":           {26.3833, 50.5833},
+	"Baghdad":           {33.35, 44.4167},
+	"Qatar":             {25.2833, 51.5333},
+	"Riyadh":            {24.6333, 46.7167},
+	"Aden":              {12.75, 45.2},
+	"Amman":             {31.95, 35.9333},
+	"Damascus":          {33.5, 36.3},
+	"Addis Ababa":       {9.0333, 38.7},
+	"Antananarivo":      {-18.9167, 47.5167},
+	"Asmara":            {15.3333, 38.8833},
+	"Dar es Salaam":     {-6.8, 39.2833},
+	"Djibouti":          {11.6, 43.15},
+	"Kampala":           {0.3167, 32.4167},
+	"Mamoudzou":         {-12.7833, 45.2333},
+	"Mogadishu":         {2.0667, 45.3667},
+	"Comoro":            {-11.6833, 43.2667},
+	"Nairobi":           {-1.2833, 36.8167},
+	"Minsk":             {53.9, 27.5667},
+	"Moscow":            {55.7558, 37.6178},
+	"Simferopol":        {44.95, 34.1},
+	"Syowa":             {-69.0061, 39.59},
+	"Istanbul":          {41.0167, 28.9667},
+	"Tehran":            {35.6667, 51.4333},
+	"Yerevan":           {40.1833, 44.5},
+	"Baku":              {40.3833, 49.85},
+	"Tbilisi":           {41.7167, 44.8167},
+	"Dubai":             {25.3, 55.3},
+	"Muscat":            {23.6, 58.5833},
+	"Mauritius":         {-20.1667, 57.5},
+	"Saint-Denis":       {-20.8667, 55.4667},
+	"Samara":            {53.2, 50.15},
+	"Mahe":              {-4.6667, 55.4667},
+	"Kabul":             {34.5167, 69.2},
+	"Almaty":            {43.25, 76.95},
+	"Port-aux-Francais": {-49.3528, 70.2175},
+	"Maldives":          {4.1667, 73.5},
+	"Mawson":            {-67.6, 62.8833},
+	"Karachi":           {24.8667, 67.05},
+	"Dushanbe":          {38.5833, 68.8},
+	"Ashgabat":          {37.95, 58.3833},
+	"Tashkent":          {41.3333, 69.3},
+	"Aqtobe":            {50.2833, 57.1667},
+	"Yekaterinburg":     {56.85, 60.6},
+	"Colombo":           {6.9333, 79.85},
+	"Kolkata":           {22.5333, 88.3667},
+	"Kathmandu":         {27.7167, 85.3167},
+	"Dhaka":             {23.7167, 90.4167},
+	"Thimphu":           {27.4667, 89.65},
+	"Urumqi":            {43.8, 87.5833},
+	"Diego Garcia":      {-7.3333, 72.4167},
+	"Bishkek":           {42.9, 74.6},
+	"Omsk":              {55, 73.4},
+	"Bantam Village":    {-12.1667, 96.9167},
+	"Yangon":            {16.7833, 96.1667},
+	"Flying Fish Cove":  {-10.4167, 105.7167},
+	"Davis":             {-68.5833, 77.9667},
+	"Hovd":              {48.0167, 91.65},
+	"Choibalsan":        {48.0667, 114.5},
+	"Bangkok":           {13.75, 100.5167},
+	"Ho Chi Minh":       {10.75, 106.6667},
+	"Phnom Penh":        {11.55, 104.9167},
+	"Vientiane":         {17.9667, 102.6},
+	"Novosibirsk":       {55.0333, 82.9167},
+	"Jakarta":           {-6.1667, 106.8},
+	"Casey":             {-66.2833, 110.5167},
+	"Perth":             {-31.95, 115.85},
+	"Brunei":            {4.9333, 114.9167},
+	"Makassar":          {-5.1167, 119.4},
+	"Macao":             {22.1972, 113.5417},
+	"Shanghai":          {31.2333, 121.4667},
+	"Hong Kong":         {22.2833, 114.15},
+	"Irkutsk":           {52.2667, 104.3333},
+	"Kuala Lumpur":      {3.1667, 101.7},
+	"Manila":            {14.5867, 120.9678},
+	"Singapore":         {1.2833, 103.85},
+	"Taipei":            {25.05, 121.5},
+	"Ulaanbaatar":       {47.9167, 106.8833},
+	"Eucla":             {-31.7167, 128.8667},
+	"Dili":              {-8.55, 125.5833},
+	"Jayapura":          {-2.5333, 140.7},
+	"Tokyo":             {35.6544, 139.7447},
+	"Pyongyang":         {39.0167, 125.75},
+	"Seoul":             {37.55, 126.9667},
+	"Koror":             {7.3333, 134.4833},
+	"Chita":             {52.05, 113.4667},
+	"Adelaide":          {-34.9167, 138.5833},
+	"Darwin":            {-12.4667, 130.8333},
+	"Brisbane":          {-27.4667, 153.0333},
+	"Sydney":            {-33.8667, 151.2167},
+	"Guam":              {13.4667, 144.75},
+	"Saipan":            {15.2, 145.75},
+	"Chuuk":             {7.4167, 151.7833},
+	"Pohnpei":           {6.9667, 158.2167},
+	"Dumont d'Urville":  {-66.6667, 140.0167},
+	"Port Moresby":      {-9.5, 147.1667},
+	"Vladivostok":       {43.1667, 131.9333},
+	"Lord Howe":         {-31.55, 159.0833},
+	"Bougainville":      {-6.2167, 155.5667},
+	"Kosrae":            {5.3167, 162.9833},
+	"Noumea":            {-22.2667, 166.45},
+	"Kingston":          {-29.05, 167.9667},
+	"Sakhalin":          {46.9667, 142.7},
+	"Honiara":           {-9.5333, 160.2},
+	"Efate":             {-17.6667, 168.4167},
+	"Suva":              {-18.1333, 178.4167},
+	"Tarawa":            {1.4167, 173},
+	"Majuro":            {7.15, 171.2},
+	"Nauru":             {-0.5167, 166.9167},
+	"Auckland":          {-36.8667, 174.7667},
+	"McMurdo":           {-77.8333, 166.6},
+	"Vostok":            {-78.4, 106.9},
+	"Kamchatka":         {53.0167, 158.65},
+	"Funafuti":          {-8.5167, 179.2167},
+	"Mata-utu":          {-13.3, -176.1667},
+	"Chatham":           {-43.95, -176.55},
+	"Apia":              {-13.8333, -171.7333},
+	"Kanton":            {-2.7833, -171.7167},
+	"Fale":              {-9.3667, -171.2333},
+	"Nuku'alofa":        {-21.1333, -175.2},
+	"Kiritimati":        {1.8667, -157.3333},
+}
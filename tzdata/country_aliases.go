@@ -0,0 +1,68 @@
+package tzdata
+
+// CountryAliases maps other names of countries (short names, former names, official names
+// and constituent countries) to their name in CountryToIanaTimezone, e.g. "Holland" to
+// "Netherlands". The names of Alpha2ToCountry and Alpha3ToCountry that differ from it,
+// like "Russian Federation", are among them.
+var CountryAliases = map[string]string{
+	// short and common names
+	"America":               "United States of America",
+	"UK":                    "United Kingdom",
+	"Britain":               "United Kingdom",
+	"Great Britain":         "United Kingdom",
+	"Holland":               "Netherlands",
+	"The Netherlands":       "Netherlands",
+	"Korea":                 "South Korea",
+	"DPRK":                  "North Korea",
+	"UAE":                   "United Arab Emirates",
+	"DRC":                   "Democratic Republic of the Congo",
+	"DR Congo":              "Democratic Republic of the Congo",
+	"Congo-Kinshasa":        "Democratic Republic of the Congo",
+	"Congo-Brazzaville":     "Republic of the Congo",
+	"Caribbean Netherlands": "Bonaire",
+	"Falklands":             "Falkland Islands",
+	"East Timor":            "Timor-Leste",
+	"Macau":                 "Macao",
+
+	// constituent countries
+	"England":          "United Kingdom",
+	"Scotland":         "United Kingdom",
+	"Wales":            "United Kingdom",
+	"Northern Ireland": "United Kingdom",
+
+	// former names
+	"Burma":       "Myanmar",
+	"Ceylon":      "Sri Lanka",
+	"Persia":      "Iran",
+	"Siam":        "Thailand",
+	"Zaire":       "Democratic Republic of the Congo",
+	"Upper Volta": "Burkina Faso",
+	"Rhodesia":    "Zimbabwe",
+
+	// official and local names
+	"Czechia":                          "Czech Republic",
+	"Côte d'Ivoire":                    "Ivory Coast",
+	"Cote d'Ivoire":                    "Ivory Coast",
+	"Russian Federation":               "Russia",
+	"Syrian Arab Republic":             "Syria",
+	"Brunei Darussalam":                "Brunei",
+	"Cabo Verde":                       "Cape Verde",
+	"Cocos (Keeling) Islands":          "Cocos Islands",
+	"Curaçao":                          "Curacao",
+	"Democratic Republic of Congo":     "Democratic Republic of the Congo",
+	"Republic of Congo":                "Republic of the Congo",
+	"Eswatini":                         "Swaziland",
+	"Vatican City":                     "Vatican",
+	"Holy See":                         "Vatican",
+	"Lao People's Democratic Republic": "Laos",
+	"North Macedonia":                  "Macedonia",
+	"Republic of North Macedonia":      "Macedonia",
+	"Reunion":                          "Réunion",
+	"Saint Helena, Ascension and Tristan da Cunha": "Saint Helena",
+	"Bonaire, Sint Eustatius and Saba":             "Bonaire",
+	"Pitcairn Islands":                             "Pitcairn",
+	"Republic of Korea":                            "South Korea",
+	"Democratic People's Republic of Korea":        "North Korea",
+	"Türkiye":                                      "Turkey",
+	"Viet Nam":                                     "Vietnam",
+}
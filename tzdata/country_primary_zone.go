@@ -0,0 +1,44 @@
+package tzdata
+
+// CountryPrimaryZone is the timezone of the capital or the most populous area of every
+// country with several timezones in CountryToIanaTimezone. It is used when a country is
+// looked up without a terminal to pick a timezone, and is the one pre-selected in the picker.
+var CountryPrimaryZone = map[string]string{
+	"Antarctica":                           "Antarctica/McMurdo",
+	"Argentina":                            "America/Argentina/Buenos_Aires",
+	"Australia":                            "Australia/Sydney",
+	"Brazil":                               "America/Sao_Paulo",
+	"Canada":                               "America/Toronto",
+	"Chile":                                "America/Santiago",
+	"China":                                "Asia/Shanghai",
+	"Cyprus":                               "Asia/Nicosia",
+	"Democratic Republic of the Congo":     "Africa/Kinshasa",
+	"Ecuador":                              "America/Guayaquil",
+	"French Polynesia":                     "Pacific/Tahiti",
+	"Germany":                              "Europe/Berlin",
+	"Greenland":                            "America/Godthab",
+	"India":                                "Asia/Kolkata",
+	"Indonesia":                            "Asia/Jakarta",
+	"Kazakhstan":                           "Asia/Almaty",
+	"Kiribati":                             "Pacific/Tarawa",
+	"Malaysia":                             "Asia/Kuala_Lumpur",
+	"Marshall Islands":                     "Pacific/Majuro",
+	"Mexico":                               "America/Mexico_City",
+	"Micronesia":                           "Pacific/Pohnpei",
+	"Mongolia":                             "Asia/Ulaanbaatar",
+	"Myanmar":                              "Asia/Yangon",
+	"Netherlands":                          "Europe/Amsterdam",
+	"New Zealand":                          "Pacific/Auckland",
+	"Palestine":                            "Asia/Hebron",
+	"Papua New Guinea":                     "Pacific/Port_Moresby",
+	"Poland":                               "Europe/Warsaw",
+	"Portugal":                             "Europe/Lisbon",
+	"Russia":                               "Europe/Moscow",
+	"Spain":                                "Europe/Madrid",
+	"Ukraine":                              "Europe/Kiev",
+	"United Kingdom":                       "Europe/London",
+	"United States Minor Outlying Islands": "Pacific/Midway",
+	"United States of America":             "America/New_York",
+	"Uzbekistan":                           "Asia/Tashkent",
+	"Vietnam":                              "Asia/Ho_Chi_Minh",
+}
@@ -11,7 +11,7 @@ var CountryToIanaTimezone = map[string][]string{
 		"Antarctica/Casey", "Antarctica/Davis", "Antarctica/DumontDUrville",
 		"Antarctica/Mawson", "Antarctica/McMurdo", "Antarctica/Palmer",
 		"Antarctica/Rothera", "Antarctica/Syowa", "Antarctica/Troll",
-		"Antarctica/vostok",
+		"Antarctica/Vostok",
 	},
 	"Antigua and Barbuda": {"America/Antigua"},
 	"Argentina": {
@@ -115,7 +115,7 @@ var CountryToIanaTimezone = map[string][]string{
 	"Martinique":                       {"America/Martinique"},
 	"Mayotte":                          {"Indian/Mayotte"},
 	"Réunion":                          {"Indian/Reunion"},
-	"Saint Pierre and Miquelon":        {"America/St_Pierre"},
+	"Saint Pierre and Miquelon":        {"America/Miquelon"},
 	"Saint Martin":                     {"America/Marigot"},
 	"Bonaire":                          {"America/Kralendijk"},
 	"Saint Barthélemy":                 {"America/Guadeloupe"},
@@ -311,13 +311,13 @@ var CountryToIanaTimezone = map[string][]string{
 		"America/Phoenix", "America/Sitka", "America/Yakutat",
 		"Pacific/Honolulu",
 	},
-	"Aland Islands":     {"Aland Islands"},
+	"Aland Islands":     {"Europe/Mariehamn"},
 	"Uruguay":           {"America/Montevideo"},
 	"Uzbekistan":        {"Asia/Samarkand", "Asia/Tashkent"},
 	"Vanuatu":           {"Pacific/Efate"},
 	"Vatican":           {"Europe/Vatican"},
 	"Venezuela":         {"America/Caracas"},
-	"Vietnam":           {"Asia/Ho_Chi_Minh", "Asia/Saigon", "Asia/Hanoi"},
+	"Vietnam":           {"Asia/Ho_Chi_Minh", "Asia/Saigon"},
 	"Wallis and Futuna": {"Pacific/Wallis"},
 	"Western Sahara":    {"Africa/El_Aaiun"},
 	"Yemen":             {"Asia/Aden"},
@@ -0,0 +1,129 @@
+//go:build ignore
+
+// gen_search_index generates search_index.bin, the prebuilt search index of the cities,
+// countries, subdivisions and zones of this package, as described in search_index.go.
+// Run it with `go generate ./tzdata` after changing the data.
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+func main() {
+	cities := map[string]string{}
+	for _, city := range sortedKeys(tzdata.CityToIanaTimezone) {
+		cities[cleanWord(city)] = city
+	}
+	countries := map[string]string{}
+	// countries are found by their aliases as well, e.g. 'holland' for Netherlands
+	for _, alias := range sortedKeys(tzdata.CountryAliases) {
+		countries[cleanWord(alias)] = tzdata.CountryAliases[alias]
+	}
+	for _, country := range sortedKeys(tzdata.CountryToIanaTimezone) {
+		countries[cleanWord(country)] = country
+	}
+	subdivisions := map[string]string{}
+	for _, code := range sortedKeys(tzdata.SubdivisionToIanaTimezone) {
+		name := tzdata.SubdivisionToIanaTimezone[code]["name"]
+		subdivisions[cleanWord(name)] = name
+	}
+	zones := map[string]string{}
+	for _, zone := range zoneNames() {
+		// a zone is found by its last part as well, e.g. 'kathmandu' for Asia/Kathmandu
+		zones[cleanWord(zone[strings.LastIndex(zone, "/")+1:])] = zone
+		zones[cleanWord(zone)] = zone
+	}
+
+	var out bytes.Buffer
+	out.WriteString(tzdata.SearchIndexMagic)
+	sections := []struct {
+		kind  string
+		words map[string]string
+	}{{"city", cities}, {"country", countries}, {"subdivision", subdivisions}, {"zone", zones}}
+	out.Write(binary.AppendUvarint(nil, uint64(len(sections))))
+	for _, section := range sections {
+		writeSection(&out, section.kind, section.words)
+	}
+	if err := os.WriteFile("search_index.bin", out.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// writeSection writes the words of one kind, sorted by their cleaned key and front-coded
+// against the previous key, with a restart point every tzdata.SearchIndexRestart words.
+func writeSection(out *bytes.Buffer, kind string, words map[string]string) {
+	keys := sortedKeys(words)
+	var entries []byte
+	var restarts []byte
+	previous := ""
+	for i, key := range keys {
+		shared := 0
+		if i%tzdata.SearchIndexRestart == 0 {
+			restarts = binary.LittleEndian.AppendUint32(restarts, uint32(len(entries)))
+		} else {
+			for shared < len(key) && shared < len(previous) && key[shared] == previous[shared] {
+				shared++
+			}
+		}
+		entries = binary.AppendUvarint(entries, uint64(shared))
+		entries = appendString(entries, key[shared:])
+		entries = appendString(entries, words[key])
+		previous = key
+	}
+	out.Write(appendString(nil, kind))
+	out.Write(binary.AppendUvarint(nil, uint64(len(keys))))
+	out.Write(binary.AppendUvarint(nil, uint64(len(entries))))
+	out.Write(entries)
+	out.Write(restarts)
+}
+
+// appendString appends a string with its length.
+func appendString(b []byte, s string) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(s))), s...)
+}
+
+// zoneNames returns the names of the zones of the embedded zoneinfo.zip, sorted.
+func zoneNames() []string {
+	archive, err := zip.NewReader(bytes.NewReader(tzdata.ZoneinfoZip), int64(len(tzdata.ZoneinfoZip)))
+	if err != nil {
+		log.Fatal(err)
+	}
+	var zones []string
+	for _, file := range archive.File {
+		if !strings.HasPrefix(file.Name, "+") && !strings.HasSuffix(file.Name, "/") {
+			zones = append(zones, file.Name)
+		}
+	}
+	sort.Strings(zones)
+	return zones
+}
+
+// cleanWord lowercases a word and drops everything but letters and digits, like the
+// search of package cmd does with queries.
+func cleanWord(word string) string {
+	var sb strings.Builder
+	for _, ch := range word {
+		if unicode.IsLetter(ch) || unicode.IsNumber(ch) {
+			sb.WriteRune(unicode.ToLower(ch))
+		}
+	}
+	return sb.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,19 @@
+package tzdata
+
+import _ "embed"
+
+// HolidaysJSON holds the public holidays of some countries as a JSON object keyed by
+// ISO alpha-2 codes (see Alpha2ToCountry), each a list of holidays like
+//
+//	{"date": "2026-10-18", "end": "2026-10-23", "name": "Dashain"}
+//
+// where "end" is the last day of a holiday lasting several days and "yearly": true
+// repeats a holiday on the same date every year. Holidays following a lunar or
+// religious calendar are listed per year; to update them, add the dates of the new
+// year and HolidaysYear.
+//
+//go:embed holidays.json
+var HolidaysJSON []byte
+
+// HolidaysYear is the last year HolidaysJSON fully covers.
+const HolidaysYear = 2026
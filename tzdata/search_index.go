@@ -0,0 +1,32 @@
+package tzdata
+
+import _ "embed"
+
+//go:generate go run gen_search_index.go
+
+// SearchIndex is the prebuilt search index of the cities, countries, subdivisions and zones,
+// generated from the other data of this package so that searches don't build one on every run.
+//
+// It starts with SearchIndexMagic and the number of sections as a uvarint. Each section holds
+// the words of one kind ("city", "country", "subdivision" or "zone"):
+//
+//	kind       uvarint length, bytes
+//	count      uvarint number of words
+//	size       uvarint length of the entries
+//	entries    one per word, sorted by key:
+//	             shared    uvarint length of the prefix shared with the previous key
+//	             suffix    uvarint length, bytes: the rest of the key
+//	             original  uvarint length, bytes: the word as shown, e.g. "New York" for "newyork"
+//	restarts   little-endian uint32 offsets into the entries of every SearchIndexRestart-th
+//	           word, which shares no prefix, for binary searches
+//
+// Keys are lowercased with everything but letters and digits removed.
+//
+//go:embed search_index.bin
+var SearchIndex []byte
+
+// SearchIndexMagic starts SearchIndex, with the version of its format.
+const SearchIndexMagic = "KTZI1"
+
+// SearchIndexRestart is the number of words between restart points of SearchIndex.
+const SearchIndexRestart = 16
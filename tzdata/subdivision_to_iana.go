@@ -0,0 +1,225 @@
+package tzdata
+
+// SubdivisionToIanaTimezone maps ISO 3166-2 subdivision codes (states, provinces, oblasts...)
+// to the subdivision name, its country and the timezone most of it uses.
+var SubdivisionToIanaTimezone = map[string]map[string]string{
+	"US-AL": {"name": "Alabama", "country": "United States of America", "tz": "America/Chicago"},
+	"US-AK": {"name": "Alaska", "country": "United States of America", "tz": "America/Anchorage"},
+	"US-AZ": {"name": "Arizona", "country": "United States of America", "tz": "America/Phoenix"},
+	"US-AR": {"name": "Arkansas", "country": "United States of America", "tz": "America/Chicago"},
+	"US-CA": {"name": "California", "country": "United States of America", "tz": "America/Los_Angeles"},
+	"US-CO": {"name": "Colorado", "country": "United States of America", "tz": "America/Denver"},
+	"US-CT": {"name": "Connecticut", "country": "United States of America", "tz": "America/New_York"},
+	"US-DE": {"name": "Delaware", "country": "United States of America", "tz": "America/New_York"},
+	"US-DC": {"name": "District of Columbia", "country": "United States of America", "tz": "America/New_York"},
+	"US-FL": {"name": "Florida", "country": "United States of America", "tz": "America/New_York"},
+	"US-GA": {"name": "Georgia", "country": "United States of America", "tz": "America/New_York"},
+	"US-HI": {"name": "Hawaii", "country": "United States of America", "tz": "Pacific/Honolulu"},
+	"US-ID": {"name": "Idaho", "country": "United States of America", "tz": "America/Boise"},
+	"US-IL": {"name": "Illinois", "country": "United States of America", "tz": "America/Chicago"},
+	"US-IN": {"name": "Indiana", "country": "United States of America", "tz": "America/Indiana/Indianapolis"},
+	"US-IA": {"name": "Iowa", "country": "United States of America", "tz": "America/Chicago"},
+	"US-KS": {"name": "Kansas", "country": "United States of America", "tz": "America/Chicago"},
+	"US-KY": {"name": "Kentucky", "country": "United States of America", "tz": "America/Kentucky/Louisville"},
+	"US-LA": {"name": "Louisiana", "country": "United States of America", "tz": "America/Chicago"},
+	"US-ME": {"name": "Maine", "country": "United States of America", "tz": "America/New_York"},
+	"US-MD": {"name": "Maryland", "country": "United States of America", "tz": "America/New_York"},
+	"US-MA": {"name": "Massachusetts", "country": "United States of America", "tz": "America/New_York"},
+	"US-MI": {"name": "Michigan", "country": "United States of America", "tz": "America/Detroit"},
+	"US-MN": {"name": "Minnesota", "country": "United States of America", "tz": "America/Chicago"},
+	"US-MS": {"name": "Mississippi", "country": "United States of America", "tz": "America/Chicago"},
+	"US-MO": {"name": "Missouri", "country": "United States of America", "tz": "America/Chicago"},
+	"US-MT": {"name": "Montana", "country": "United States of America", "tz": "America/Denver"},
+	"US-NE": {"name": "Nebraska", "country": "United States of America", "tz": "America/Chicago"},
+	"US-NV": {"name": "Nevada", "country": "United States of America", "tz": "America/Los_Angeles"},
+	"US-NH": {"name": "New Hampshire", "country": "United States of America", "tz": "America/New_York"},
+	"US-NJ": {"name": "New Jersey", "country": "United States of America", "tz": "America/New_York"},
+	"US-NM": {"name": "New Mexico", "country": "United States of America", "tz": "America/Denver"},
+	"US-NY": {"name": "New York", "country": "United States of America", "tz": "America/New_York"},
+	"US-NC": {"name": "North Carolina", "country": "United States of America", "tz": "America/New_York"},
+	"US-ND": {"name": "North Dakota", "country": "United States of America", "tz": "America/Chicago"},
+	"US-OH": {"name": "Ohio", "country": "United States of America", "tz": "America/New_York"},
+	"US-OK": {"name": "Oklahoma", "country": "United States of America", "tz": "America/Chicago"},
+	"US-OR": {"name": "Oregon", "country": "United States of America", "tz": "America/Los_Angeles"},
+	"US-PA": {"name": "Pennsylvania", "country": "United States of America", "tz": "America/New_York"},
+	"US-RI": {"name": "Rhode Island", "country": "United States of America", "tz": "America/New_York"},
+	"US-SC": {"name": "South Carolina", "country": "United States of America", "tz": "America/New_York"},
+	"US-SD": {"name": "South Dakota", "country": "United States of America", "tz": "America/Chicago"},
+	"US-TN": {"name": "Tennessee", "country": "United States of America", "tz": "America/Chicago"},
+	"US-TX": {"name": "Texas", "country": "United States of America", "tz": "America/Chicago"},
+	"US-UT": {"name": "Utah", "country": "United States of America", "tz": "America/Denver"},
+	"US-VT": {"name": "Vermont", "country": "United States of America", "tz": "America/New_York"},
+	"US-VA": {"name": "Virginia", "country": "United States of America", "tz": "America/New_York"},
+	"US-WA": {"name": "Washington", "country": "United States of America", "tz": "America/Los_Angeles"},
+	"US-WV": {"name": "West Virginia", "country": "United States of America", "tz": "America/New_York"},
+	"US-WI": {"name": "Wisconsin", "country": "United States of America", "tz": "America/Chicago"},
+	"US-WY": {"name": "Wyoming", "country": "United States of America", "tz": "America/Denver"},
+
+	"CA-AB": {"name": "Alberta", "country": "Canada", "tz": "America/Edmonton"},
+	"CA-BC": {"name": "British Columbia", "country": "Canada", "tz": "America/Vancouver"},
+	"CA-MB": {"name": "Manitoba", "country": "Canada", "tz": "America/Winnipeg"},
+	"CA-NB": {"name": "New Brunswick", "country": "Canada", "tz": "America/Moncton"},
+	"CA-NL": {"name": "Newfoundland and Labrador", "country": "Canada", "tz": "America/St_Johns"},
+	"CA-NS": {"name": "Nova Scotia", "country": "Canada", "tz": "America/Halifax"},
+	"CA-NT": {"name": "Northwest Territories", "country": "Canada", "tz": "America/Yellowknife"},
+	"CA-NU": {"name": "Nunavut", "country": "Canada", "tz": "America/Iqaluit"},
+	"CA-ON": {"name": "Ontario", "country": "Canada", "tz": "America/Toronto"},
+	"CA-PE": {"name": "Prince Edward Island", "country": "Canada", "tz": "America/Halifax"},
+	"CA-QC": {"name": "Quebec", "country": "Canada", "tz": "America/Toronto"},
+	"CA-SK": {"name": "Saskatchewan", "country": "Canada", "tz": "America/Regina"},
+	"CA-YT": {"name": "Yukon", "country": "Canada", "tz": "America/Whitehorse"},
+
+	"AU-NSW": {"name": "New South Wales", "country": "Australia", "tz": "Australia/Sydney"},
+	"AU-VIC": {"name": "Victoria", "country": "Australia", "tz": "Australia/Melbourne"},
+	"AU-QLD": {"name": "Queensland", "country": "Australia", "tz": "Australia/Brisbane"},
+	"AU-SA":  {"name": "South Australia", "country": "Australia", "tz": "Australia/Adelaide"},
+	"AU-WA":  {"name": "Western Australia", "country": "Australia", "tz": "Australia/Perth"},
+	"AU-TAS": {"name": "Tasmania", "country": "Australia", "tz": "Australia/Hobart"},
+	"AU-NT":  {"name": "Northern Territory", "country": "Australia", "tz": "Australia/Darwin"},
+	"AU-ACT": {"name": "Australian Capital Territory", "country": "Australia", "tz": "Australia/Sydney"},
+
+	"BR-AC": {"name": "Acre", "country": "Brazil", "tz": "America/Rio_Branco"},
+	"BR-AL": {"name": "Alagoas", "country": "Brazil", "tz": "America/Maceio"},
+	"BR-AP": {"name": "Amapa", "country": "Brazil", "tz": "America/Belem"},
+	"BR-AM": {"name": "Amazonas", "country": "Brazil", "tz": "America/Manaus"},
+	"BR-BA": {"name": "Bahia", "country": "Brazil", "tz": "America/Bahia"},
+	"BR-CE": {"name": "Ceara", "country": "Brazil", "tz": "America/Fortaleza"},
+	"BR-DF": {"name": "Distrito Federal", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-ES": {"name": "Espirito Santo", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-GO": {"name": "Goias", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-MA": {"name": "Maranhao", "country": "Brazil", "tz": "America/Fortaleza"},
+	"BR-MT": {"name": "Mato Grosso", "country": "Brazil", "tz": "America/Cuiaba"},
+	"BR-MS": {"name": "Mato Grosso do Sul", "country": "Brazil", "tz": "America/Campo_Grande"},
+	"BR-MG": {"name": "Minas Gerais", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-PA": {"name": "Para", "country": "Brazil", "tz": "America/Belem"},
+	"BR-PB": {"name": "Paraiba", "country": "Brazil", "tz": "America/Fortaleza"},
+	"BR-PR": {"name": "Parana", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-PE": {"name": "Pernambuco", "country": "Brazil", "tz": "America/Recife"},
+	"BR-PI": {"name": "Piaui", "country": "Brazil", "tz": "America/Fortaleza"},
+	"BR-RJ": {"name": "Rio de Janeiro", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-RN": {"name": "Rio Grande do Norte", "country": "Brazil", "tz": "America/Fortaleza"},
+	"BR-RS": {"name": "Rio Grande do Sul", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-RO": {"name": "Rondonia", "country": "Brazil", "tz": "America/Porto_Velho"},
+	"BR-RR": {"name": "Roraima", "country": "Brazil", "tz": "America/Boa_Vista"},
+	"BR-SC": {"name": "Santa Catarina", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-SP": {"name": "Sao Paulo", "country": "Brazil", "tz": "America/Sao_Paulo"},
+	"BR-SE": {"name": "Sergipe", "country": "Brazil", "tz": "America/Maceio"},
+	"BR-TO": {"name": "Tocantins", "country": "Brazil", "tz": "America/Araguaina"},
+
+	"RU-AD":  {"name": "Adygea", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-AL":  {"name": "Altai Republic", "country": "Russia", "tz": "Asia/Barnaul"},
+	"RU-ALT": {"name": "Altai Krai", "country": "Russia", "tz": "Asia/Barnaul"},
+	"RU-AMU": {"name": "Amur Oblast", "country": "Russia", "tz": "Asia/Yakutsk"},
+	"RU-ARK": {"name": "Arkhangelsk Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-AST": {"name": "Astrakhan Oblast", "country": "Russia", "tz": "Europe/Astrakhan"},
+	"RU-BA":  {"name": "Bashkortostan", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-BEL": {"name": "Belgorod Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-BRY": {"name": "Bryansk Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-BU":  {"name": "Buryatia", "country": "Russia", "tz": "Asia/Irkutsk"},
+	"RU-CE":  {"name": "Chechnya", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-CHE": {"name": "Chelyabinsk Oblast", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-CHU": {"name": "Chukotka", "country": "Russia", "tz": "Asia/Anadyr"},
+	"RU-CU":  {"name": "Chuvashia", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-DA":  {"name": "Dagestan", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-IN":  {"name": "Ingushetia", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-IRK": {"name": "Irkutsk Oblast", "country": "Russia", "tz": "Asia/Irkutsk"},
+	"RU-IVA": {"name": "Ivanovo Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KB":  {"name": "Kabardino-Balkaria", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KGD": {"name": "Kaliningrad Oblast", "country": "Russia", "tz": "Europe/Kaliningrad"},
+	"RU-KL":  {"name": "Kalmykia", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KLU": {"name": "Kaluga Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KAM": {"name": "Kamchatka Krai", "country": "Russia", "tz": "Asia/Kamchatka"},
+	"RU-KC":  {"name": "Karachay-Cherkessia", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KR":  {"name": "Karelia", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KEM": {"name": "Kemerovo Oblast", "country": "Russia", "tz": "Asia/Novokuznetsk"},
+	"RU-KHA": {"name": "Khabarovsk Krai", "country": "Russia", "tz": "Asia/Vladivostok"},
+	"RU-KK":  {"name": "Khakassia", "country": "Russia", "tz": "Asia/Krasnoyarsk"},
+	"RU-KHM": {"name": "Khanty-Mansi Autonomous Okrug", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-KIR": {"name": "Kirov Oblast", "country": "Russia", "tz": "Europe/Kirov"},
+	"RU-KO":  {"name": "Komi", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KOS": {"name": "Kostroma Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KDA": {"name": "Krasnodar Krai", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-KYA": {"name": "Krasnoyarsk Krai", "country": "Russia", "tz": "Asia/Krasnoyarsk"},
+	"RU-KGN": {"name": "Kurgan Oblast", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-KRS": {"name": "Kursk Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-LEN": {"name": "Leningrad Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-LIP": {"name": "Lipetsk Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-MAG": {"name": "Magadan Oblast", "country": "Russia", "tz": "Asia/Magadan"},
+	"RU-ME":  {"name": "Mari El", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-MO":  {"name": "Mordovia", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-MOS": {"name": "Moscow Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-MOW": {"name": "Moscow City", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-MUR": {"name": "Murmansk Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-NEN": {"name": "Nenets Autonomous Okrug", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-NIZ": {"name": "Nizhny Novgorod Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-NGR": {"name": "Novgorod Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-NVS": {"name": "Novosibirsk Oblast", "country": "Russia", "tz": "Asia/Novosibirsk"},
+	"RU-OMS": {"name": "Omsk Oblast", "country": "Russia", "tz": "Asia/Omsk"},
+	"RU-ORE": {"name": "Orenburg Oblast", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-ORL": {"name": "Oryol Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-PNZ": {"name": "Penza Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-PER": {"name": "Perm Krai", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-PRI": {"name": "Primorsky Krai", "country": "Russia", "tz": "Asia/Vladivostok"},
+	"RU-PSK": {"name": "Pskov Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-ROS": {"name": "Rostov Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-RYA": {"name": "Ryazan Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-SA":  {"name": "Sakha (Yakutia)", "country": "Russia", "tz": "Asia/Yakutsk"},
+	"RU-SAK": {"name": "Sakhalin Oblast", "country": "Russia", "tz": "Asia/Sakhalin"},
+	"RU-SAM": {"name": "Samara Oblast", "country": "Russia", "tz": "Europe/Samara"},
+	"RU-SPE": {"name": "Saint Petersburg", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-SAR": {"name": "Saratov Oblast", "country": "Russia", "tz": "Europe/Saratov"},
+	"RU-SE":  {"name": "North Ossetia-Alania", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-SMO": {"name": "Smolensk Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-STA": {"name": "Stavropol Krai", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-SVE": {"name": "Sverdlovsk Oblast", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-TAM": {"name": "Tambov Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-TA":  {"name": "Tatarstan", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-TOM": {"name": "Tomsk Oblast", "country": "Russia", "tz": "Asia/Tomsk"},
+	"RU-TUL": {"name": "Tula Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-TVE": {"name": "Tver Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-TYU": {"name": "Tyumen Oblast", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-TY":  {"name": "Tuva", "country": "Russia", "tz": "Asia/Krasnoyarsk"},
+	"RU-UD":  {"name": "Udmurtia", "country": "Russia", "tz": "Europe/Samara"},
+	"RU-ULY": {"name": "Ulyanovsk Oblast", "country": "Russia", "tz": "Europe/Ulyanovsk"},
+	"RU-VLA": {"name": "Vladimir Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-VGG": {"name": "Volgograd Oblast", "country": "Russia", "tz": "Europe/Volgograd"},
+	"RU-VLG": {"name": "Vologda Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-VOR": {"name": "Voronezh Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-YAN": {"name": "Yamalo-Nenets Autonomous Okrug", "country": "Russia", "tz": "Asia/Yekaterinburg"},
+	"RU-YAR": {"name": "Yaroslavl Oblast", "country": "Russia", "tz": "Europe/Moscow"},
+	"RU-YEV": {"name": "Jewish Autonomous Oblast", "country": "Russia", "tz": "Asia/Vladivostok"},
+	"RU-ZAB": {"name": "Zabaykalsky Krai", "country": "Russia", "tz": "Asia/Chita"},
+
+	"MX-AGU": {"name": "Aguascalientes", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-BCN": {"name": "Baja California", "country": "Mexico", "tz": "America/Tijuana"},
+	"MX-BCS": {"name": "Baja California Sur", "country": "Mexico", "tz": "America/Mazatlan"},
+	"MX-CAM": {"name": "Campeche", "country": "Mexico", "tz": "America/Merida"},
+	"MX-CHP": {"name": "Chiapas", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-CHH": {"name": "Chihuahua", "country": "Mexico", "tz": "America/Chihuahua"},
+	"MX-CMX": {"name": "Ciudad de Mexico", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-COA": {"name": "Coahuila", "country": "Mexico", "tz": "America/Monterrey"},
+	"MX-COL": {"name": "Colima", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-DUR": {"name": "Durango", "country": "Mexico", "tz": "America/Monterrey"},
+	"MX-GUA": {"name": "Guanajuato", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-GRO": {"name": "Guerrero", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-HID": {"name": "Hidalgo", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-JAL": {"name": "Jalisco", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-MEX": {"name": "Estado de Mexico", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-MIC": {"name": "Michoacan", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-MOR": {"name": "Morelos", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-NAY": {"name": "Nayarit", "country": "Mexico", "tz": "America/Mazatlan"},
+	"MX-NLE": {"name": "Nuevo Leon", "country": "Mexico", "tz": "America/Monterrey"},
+	"MX-OAX": {"name": "Oaxaca", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-PUE": {"name": "Puebla", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-QUE": {"name": "Queretaro", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-ROO": {"name": "Quintana Roo", "country": "Mexico", "tz": "America/Cancun"},
+	"MX-SLP": {"name": "San Luis Potosi", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-SIN": {"name": "Sinaloa", "country": "Mexico", "tz": "America/Mazatlan"},
+	"MX-SON": {"name": "Sonora", "country": "Mexico", "tz": "America/Hermosillo"},
+	"MX-TAB": {"name": "Tabasco", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-TAM": {"name": "Tamaulipas", "country": "Mexico", "tz": "America/Monterrey"},
+	"MX-TLA": {"name": "Tlaxcala", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-VER": {"name": "Veracruz", "country": "Mexico", "tz": "America/Mexico_City"},
+	"MX-YUC": {"name": "Yucatan", "country": "Mexico", "tz": "America/Merida"},
+	"MX-ZAC": {"name": "Zacatecas", "country": "Mexico", "tz": "America/Mexico_City"},
+}
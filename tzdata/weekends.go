@@ -0,0 +1,34 @@
+package tzdata
+
+import "time"
+
+// DefaultWeekend is the weekend of the countries missing from CountryWeekend.
+var DefaultWeekend = []time.Weekday{time.Saturday, time.Sunday}
+
+// CountryWeekend maps the countries whose official weekend isn't Saturday and Sunday
+// (keyed like CountryToIanaTimezone) to their weekend days.
+var CountryWeekend = map[string][]time.Weekday{
+	"Afghanistan":  {time.Friday, time.Saturday},
+	"Algeria":      {time.Friday, time.Saturday},
+	"Bahrain":      {time.Friday, time.Saturday},
+	"Bangladesh":   {time.Friday, time.Saturday},
+	"Brunei":       {time.Friday, time.Sunday},
+	"Djibouti":     {time.Friday},
+	"Egypt":        {time.Friday, time.Saturday},
+	"Iran":         {time.Friday},
+	"Iraq":         {time.Friday, time.Saturday},
+	"Israel":       {time.Friday, time.Saturday},
+	"Jordan":       {time.Friday, time.Saturday},
+	"Kuwait":       {time.Friday, time.Saturday},
+	"Libya":        {time.Friday, time.Saturday},
+	"Maldives":     {time.Friday, time.Saturday},
+	"Nepal":        {time.Saturday},
+	"Oman":         {time.Friday, time.Saturday},
+	"Palestine":    {time.Friday, time.Saturday},
+	"Qatar":        {time.Friday, time.Saturday},
+	"Saudi Arabia": {time.Friday, time.Saturday},
+	"Somalia":      {time.Thursday, time.Friday},
+	"Sudan":        {time.Friday, time.Saturday},
+	"Syria":        {time.Friday, time.Saturday},
+	"Yemen":        {time.Friday, time.Saturday},
+}
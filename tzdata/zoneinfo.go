@@ -0,0 +1,15 @@
+package tzdata
+
+import _ "embed"
+
+// ZoneinfoZip is a snapshot of the IANA timezone database in the zoneinfo.zip format
+// used by Go: one uncompressed TZif file per zone, named like "Asia/Kathmandu".
+// It is the fallback when the host has no zoneinfo, e.g. in minimal containers.
+// To update it, replace zoneinfo.zip with $GOROOT/lib/time/zoneinfo.zip of a newer Go
+// release (or one built with its update.bash) and update ZoneinfoVersion.
+//
+//go:embed zoneinfo.zip
+var ZoneinfoZip []byte
+
+// ZoneinfoVersion is the IANA tzdata release of ZoneinfoZip.
+const ZoneinfoVersion = "2026c"
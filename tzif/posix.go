@@ -0,0 +1,376 @@
+package tzif
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// RuleKind is the way a POSIX TZ rule gives the day of a DST transition.
+type RuleKind int
+
+const (
+	JulianDay    RuleKind = iota // "Jn": day n from 1 to 365, February 29 is never counted
+	ZeroBasedDay                 // "n": day n from 0 to 365, February 29 is counted in leap years
+	MonthWeekDay                 // "Mm.w.d": weekday d (0 is Sunday) of week w (5 is the last) of month m
+)
+
+// Rule is the date and time of a DST transition in a POSIX TZ string.
+type Rule struct {
+	Kind  RuleKind
+	Day   int // The day for JulianDay and ZeroBasedDay, the weekday for MonthWeekDay
+	Week  int
+	Month int
+	Time  int32 // Seconds after local midnight; may be negative or beyond 24 hours (version 3+)
+}
+
+// POSIXTZ is a parsed POSIX TZ string like "EST5EDT,M3.2.0,M11.1.0" or "<+0545>-5:45",
+// the format of the TZ environment variable and of the footer of TZif files.
+type POSIXTZ struct {
+	StdName   string
+	StdOffset int32 // The UTC offset of standard time in seconds, east of UTC positive
+	DSTName   string
+	DSTOffset int32 // The UTC offset of daylight saving time in seconds
+	Start     Rule  // When DST starts, in local standard time
+	End       Rule  // When DST ends, in local daylight saving time
+}
+
+// defaultRules are the DST rules assumed when a TZ string names a DST zone without rules, as glibc does.
+var defaultRules = [2]Rule{
+	{Kind: MonthWeekDay, Month: 3, Week: 2, Day: 0, Time: 7200},
+	{Kind: MonthWeekDay, Month: 11, Week: 1, Day: 0, Time: 7200},
+}
+
+// HasDST reports whether the TZ string has daylight saving time.
+func (tz POSIXTZ) HasDST() bool {
+	return tz.DSTName != ""
+}
+
+// ParsePOSIX parses a POSIX TZ string as extended by RFC 8536, e.g. "CET-1CEST,M3.5.0,M10.5.0/3".
+func ParsePOSIX(s string) (POSIXTZ, error) {
+	var tz POSIXTZ
+	p := posixParser{s: s}
+	var err error
+	if tz.StdName, err = p.name(); err != nil {
+		return tz, err
+	}
+	offset, err := p.offset(24)
+	if err != nil {
+		return tz, err
+	}
+	// TZ strings give the offset west of UTC
+	tz.StdOffset = -offset
+	if p.done() {
+		return tz, nil
+	}
+
+	if tz.DSTName, err = p.name(); err != nil {
+		return tz, err
+	}
+	tz.DSTOffset = tz.StdOffset + 3600
+	if !p.done() && p.peek() != ',' {
+		if offset, err = p.offset(24); err != nil {
+			return tz, err
+		}
+		tz.DSTOffset = -offset
+	}
+	if p.done() {
+		tz.Start, tz.End = defaultRules[0], defaultRules[1]
+		return tz, nil
+	}
+	for _, rule := range []*Rule{&tz.Start, &tz.End} {
+		if !p.consume(',') {
+			return tz, p.errorf("expected ','")
+		}
+		if *rule, err = p.rule(); err != nil {
+			return tz, err
+		}
+	}
+	if !p.done() {
+		return tz, p.errorf("unexpected %q", p.s[p.i:])
+	}
+	return tz, nil
+}
+
+// posixParser reads a POSIX TZ string from left to right.
+type posixParser struct {
+	s string
+	i int
+}
+
+func (p *posixParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *posixParser) peek() byte {
+	if p.done() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+func (p *posixParser) consume(c byte) bool {
+	if p.peek() == c {
+		p.i++
+		return true
+	}
+	return false
+}
+
+func (p *posixParser) errorf(format string, args ...any) error {
+	return fmt.Errorf("tzif: invalid TZ string %q at offset %d: %s", p.s, p.i, fmt.Sprintf(format, args...))
+}
+
+// name reads a zone abbreviation: at least three letters, or at least three letters,
+// digits, '+' or '-' between angle brackets.
+func (p *posixParser) name() (string, error) {
+	start := p.i
+	if p.consume('<') {
+		for !p.done() && p.peek() != '>' {
+			c := p.peek()
+			if !isLetter(c) && !isDigit(c) && c != '+' && c != '-' {
+				return "", p.errorf("invalid character %q in quoted name", c)
+			}
+			p.i++
+		}
+		if !p.consume('>') {
+			return "", p.errorf("unterminated quoted name")
+		}
+		name := p.s[start+1 : p.i-1]
+		if len(name) < 3 {
+			return "", p.errorf("name %q is shorter than three characters", name)
+		}
+		return name, nil
+	}
+	for isLetter(p.peek()) {
+		p.i++
+	}
+	if name := p.s[start:p.i]; len(name) >= 3 {
+		return name, nil
+	}
+	return "", p.errorf("expected a name of at least three letters")
+}
+
+// offset reads a signed time like "-5:45" or "+167" of at most 'maxHours' hours, in seconds.
+func (p *posixParser) offset(maxHours int) (int32, error) {
+	sign := int32(1)
+	if p.consume('-') {
+		sign = -1
+	} else {
+		p.consume('+')
+	}
+	limits := []int{maxHours, 59, 59}
+	var seconds int32
+	for part, unit := range []int32{3600, 60, 1} {
+		if part > 0 && !p.consume(':') {
+			break
+		}
+		start := p.i
+		for isDigit(p.peek()) {
+			p.i++
+		}
+		if p.i == start || p.i-start > 3 {
+			return 0, p.errorf("expected a number")
+		}
+		n, _ := strconv.Atoi(p.s[start:p.i])
+		if n > limits[part] {
+			return 0, p.errorf("%d is out of range", n)
+		}
+		seconds += int32(n) * unit
+	}
+	return sign * seconds, nil
+}
+
+// number reads an unsigned number from 'min' to 'max'.
+func (p *posixParser) number(min, max int) (int, error) {
+	start := p.i
+	for isDigit(p.peek()) {
+		p.i++
+	}
+	n, err := strconv.Atoi(p.s[start:p.i])
+	if err != nil || n < min || n > max {
+		return 0, p.errorf("expected a number from %d to %d", min, max)
+	}
+	return n, nil
+}
+
+// rule reads a transition rule like "M3.2.0", "J60/1" or "59/-1".
+func (p *posixParser) rule() (Rule, error) {
+	rule := Rule{Time: 7200}
+	var err error
+	switch {
+	case p.consume('J'):
+		rule.Kind = JulianDay
+		rule.Day, err = p.number(1, 365)
+	case p.consume('M'):
+		rule.Kind = MonthWeekDay
+		if rule.Month, err = p.number(1, 12); err != nil {
+			return rule, err
+		}
+		if !p.consume('.') {
+			return rule, p.errorf("expected '.'")
+		}
+		if rule.Week, err = p.number(1, 5); err != nil {
+			return rule, err
+		}
+		if !p.consume('.') {
+			return rule, p.errorf("expected '.'")
+		}
+		rule.Day, err = p.number(0, 6)
+	default:
+		rule.Kind = ZeroBasedDay
+		rule.Day, err = p.number(0, 365)
+	}
+	if err != nil {
+		return rule, err
+	}
+	if p.consume('/') {
+		rule.Time, err = p.offset(167)
+	}
+	return rule, err
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// String formats the TZ string, quoting names that aren't all letters and leaving out
+// the DST offset and transition times that are the default.
+func (tz POSIXTZ) String() string {
+	var b strings.Builder
+	b.WriteString(formatName(tz.StdName))
+	b.WriteString(formatSeconds(-tz.StdOffset))
+	if !tz.HasDST() {
+		return b.String()
+	}
+	b.WriteString(formatName(tz.DSTName))
+	if tz.DSTOffset != tz.StdOffset+3600 {
+		b.WriteString(formatSeconds(-tz.DSTOffset))
+	}
+	for _, rule := range []Rule{tz.Start, tz.End} {
+		b.WriteByte(',')
+		b.WriteString(rule.String())
+	}
+	return b.String()
+}
+
+// String formats the rule like "M3.2.0" or "J60/1".
+func (r Rule) String() string {
+	var s string
+	switch r.Kind {
+	case JulianDay:
+		s = fmt.Sprintf("J%d", r.Day)
+	case MonthWeekDay:
+		s = fmt.Sprintf("M%d.%d.%d", r.Month, r.Week, r.Day)
+	default:
+		s = strconv.Itoa(r.Day)
+	}
+	if r.Time != 7200 {
+		s += "/" + formatSeconds(r.Time)
+	}
+	return s
+}
+
+// formatName formats a zone abbreviation, between angle brackets unless it's all letters.
+func formatName(name string) string {
+	for i := 0; i < len(name); i++ {
+		if !isLetter(name[i]) {
+			return "<" + name + ">"
+		}
+	}
+	return name
+}
+
+// formatSeconds formats seconds like "5", "-5:45" or "-1:00:30".
+func formatSeconds(seconds int32) string {
+	sign := ""
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+	s := fmt.Sprintf("%s%d", sign, seconds/3600)
+	if minutes, rest := seconds%3600/60, seconds%60; minutes != 0 || rest != 0 {
+		s += fmt.Sprintf(":%02d", minutes)
+		if rest != 0 {
+			s += fmt.Sprintf(":%02d", rest)
+		}
+	}
+	return s
+}
+
+// isLeap reports whether 'year' is a leap year in the Gregorian calendar.
+func isLeap(year int) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysBefore returns the number of days from January 1 to the first day of each month.
+func daysBefore(year int) [13]int {
+	lengths := [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+	if isLeap(year) {
+		lengths[1] = 29
+	}
+	var days [13]int
+	for i, length := range lengths {
+		days[i+1] = days[i] + length
+	}
+	return days
+}
+
+// daysSinceEpoch returns the number of days from 1970-01-01 to January 1 of 'year'.
+func daysSinceEpoch(year int) int64 {
+	y := int64(year - 1)
+	return 365*(y-1969) + (y/4 - 1969/4) - (y/100 - 1969/100) + (y/400 - 1969/400)
+}
+
+// YearDay returns the day of the year, 0 being January 1, on which the rule applies in 'year'.
+func (r Rule) YearDay(year int) int {
+	switch r.Kind {
+	case JulianDay:
+		day := r.Day - 1
+		if isLeap(year) && day >= 59 {
+			day++
+		}
+		return day
+	case ZeroBasedDay:
+		return r.Day
+	}
+	days := daysBefore(year)
+	first := days[r.Month-1]
+	// 1970-01-01 was a Thursday
+	firstWeekday := int((daysSinceEpoch(year)+int64(first))%7+4+7) % 7
+	day := first + (r.Day-firstWeekday+7)%7 + (r.Week-1)*7
+	for day >= days[r.Month] {
+		day -= 7
+	}
+	return day
+}
+
+// Transitions returns when DST starts and ends in 'year', in seconds since the Unix epoch.
+// It returns false if the TZ string has no DST.
+func (tz POSIXTZ) Transitions(year int) (start, end int64, ok bool) {
+	if !tz.HasDST() {
+		return 0, 0, false
+	}
+	midnight := daysSinceEpoch(year) * 86400
+	start = midnight + int64(tz.Start.YearDay(year))*86400 + int64(tz.Start.Time) - int64(tz.StdOffset)
+	end = midnight + int64(tz.End.YearDay(year))*86400 + int64(tz.End.Time) - int64(tz.DSTOffset)
+	return start, end, true
+}
+
+// File returns a version 2 TZif file without transitions whose footer is the TZ string,
+// so that the rules apply for any year.
+func (tz POSIXTZ) File() *File {
+	f := &File{
+		Version: 2,
+		Types:   []LocalTimeType{{Offset: tz.StdOffset, Abbreviation: tz.StdName}},
+		Footer:  tz.String(),
+	}
+	if tz.HasDST() {
+		f.Types = append(f.Types, LocalTimeType{Offset: tz.DSTOffset, IsDST: true, Abbreviation: tz.DSTName})
+	}
+	return f
+}
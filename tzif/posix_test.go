@@ -0,0 +1,122 @@
+package tzif
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePOSIX(t *testing.T) {
+	tests := []struct {
+		given string
+		want  POSIXTZ
+	}{
+		{"<+0545>-5:45", POSIXTZ{StdName: "+0545", StdOffset: 20700}},
+		{"UTC0", POSIXTZ{StdName: "UTC"}},
+		{"EST5EDT,M3.2.0,M11.1.0", POSIXTZ{
+			StdName: "EST", StdOffset: -18000, DSTName: "EDT", DSTOffset: -14400,
+			Start: Rule{Kind: MonthWeekDay, Month: 3, Week: 2, Time: 7200},
+			End:   Rule{Kind: MonthWeekDay, Month: 11, Week: 1, Time: 7200},
+		}},
+		{"EST5EDT", POSIXTZ{
+			StdName: "EST", StdOffset: -18000, DSTName: "EDT", DSTOffset: -14400,
+			Start: defaultRules[0], End: defaultRules[1],
+		}},
+		{"<-03>3<-02>,M3.5.0/-2,M10.5.0/-1", POSIXTZ{
+			StdName: "-03", StdOffset: -10800, DSTName: "-02", DSTOffset: -7200,
+			Start: Rule{Kind: MonthWeekDay, Month: 3, Week: 5, Time: -7200},
+			End:   Rule{Kind: MonthWeekDay, Month: 10, Week: 5, Time: -3600},
+		}},
+		{"IST-2IDT,M3.4.4/26,M10.5.0", POSIXTZ{
+			StdName: "IST", StdOffset: 7200, DSTName: "IDT", DSTOffset: 10800,
+			Start: Rule{Kind: MonthWeekDay, Month: 3, Week: 4, Day: 4, Time: 93600},
+			End:   Rule{Kind: MonthWeekDay, Month: 10, Week: 5, Time: 7200},
+		}},
+		{"XXX3YYY2:30,J60/1,300", POSIXTZ{
+			StdName: "XXX", StdOffset: -10800, DSTName: "YYY", DSTOffset: -9000,
+			Start: Rule{Kind: JulianDay, Day: 60, Time: 3600},
+			End:   Rule{Kind: ZeroBasedDay, Day: 300, Time: 7200},
+		}},
+	}
+	for _, test := range tests {
+		got, err := ParsePOSIX(test.given)
+		if err != nil {
+			t.Fatalf("ParsePOSIX(%v) returned error %v", test.given, err)
+		}
+		if got != test.want {
+			t.Fatalf("ParsePOSIX(%v) = %+v, want %+v", test.given, got, test.want)
+		}
+		// formatting gives the same rules back
+		if again, err := ParsePOSIX(got.String()); err != nil || again != got {
+			t.Fatalf("ParsePOSIX(%v) = %+v, %v, want %+v", got.String(), again, err, got)
+		}
+	}
+}
+
+func TestParsePOSIXErrors(t *testing.T) {
+	for _, given := range []string{"", "E5", "EST", "EST25", "<+05", "EST5EDT,M13.1.0,M11.1.0", "EST5EDT,M3.2.0", "EST5EDT,M3.2.0,M11.1.0x"} {
+		if _, err := ParsePOSIX(given); err == nil {
+			t.Fatalf("ParsePOSIX(%q) returned no error", given)
+		}
+	}
+}
+
+func TestPOSIXTransitions(t *testing.T) {
+	// the rules give the same transitions as the zones they describe
+	tests := []struct {
+		given string
+		zone  string
+	}{
+		{"EST5EDT,M3.2.0,M11.1.0", "America/New_York"},
+		{"CET-1CEST,M3.5.0,M10.5.0/3", "Europe/Berlin"},
+		{"AEST-10AEDT,M10.1.0,M4.1.0/3", "Australia/Sydney"},
+		{"<+1030>-10:30<+11>-11,M10.1.0,M4.1.0", "Australia/Lord_Howe"},
+	}
+	for _, test := range tests {
+		tz, _ := ParsePOSIX(test.given)
+		loc, err := time.LoadLocationFromTZData(test.zone, readEmbeddedZone(t, test.zone))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for year := 2024; year <= 2040; year++ {
+			start, end, ok := tz.Transitions(year)
+			if !ok {
+				t.Fatalf("%v has no transitions", test.given)
+			}
+			for _, transition := range []int64{start, end} {
+				_, before := time.Unix(transition-1, 0).In(loc).Zone()
+				_, after := time.Unix(transition, 0).In(loc).Zone()
+				if before == after {
+					t.Fatalf("%v: %v in %v is no transition", test.given, time.Unix(transition, 0).UTC(), test.zone)
+				}
+			}
+		}
+	}
+}
+
+func TestPOSIXFile(t *testing.T) {
+	tz, _ := ParsePOSIX("EST5EDT,M3.2.0,M11.1.0")
+	data := Encode(tz.File())
+	f, err := Parse(data)
+	if err != nil {
+		t.Fatalf("Parse(Encode()) returned error %v", err)
+	}
+	if f.Footer != "EST5EDT,M3.2.0,M11.1.0" || len(f.Types) != 2 {
+		t.Fatalf("Parse(Encode()) = %+v", f)
+	}
+	loc, err := time.LoadLocationFromTZData("EST5EDT", data)
+	if err != nil {
+		t.Fatalf("time.LoadLocationFromTZData() returned error %v", err)
+	}
+	for _, test := range []struct {
+		given time.Time
+		want  string
+	}{
+		{time.Date(2099, 1, 15, 12, 0, 0, 0, time.UTC), "EST"},
+		{time.Date(2099, 7, 15, 12, 0, 0, 0, time.UTC), "EDT"},
+		{time.Date(1950, 7, 15, 12, 0, 0, 0, time.UTC), "EDT"},
+	} {
+		if got, _ := test.given.In(loc).Zone(); got != test.want {
+			t.Fatalf("zone at %v = %v, want %v", test.given, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,335 @@
+// Package tzif parses TZif files, the binary timezone files found in zoneinfo
+// directories and in /etc/localtime, as specified by RFC 8536 (versions 1 to 4).
+// It does not depend on the internals of Go's time package, so it can describe
+// files that package would reject or interpret silently. It also parses the POSIX TZ
+// strings found in the footer of TZif files and encodes TZif files.
+package tzif
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Header holds the counts of the header of a TZif data block.
+type Header struct {
+	IsUTCCount  uint32 // The number of UT/local indicators
+	IsStdCount  uint32 // The number of standard/wall indicators
+	LeapCount   uint32 // The number of leap second records
+	TimeCount   uint32 // The number of transition times
+	TypeCount   uint32 // The number of local time types
+	CharCount   uint32 // The number of bytes of time zone abbreviations
+	TimeSize    int    // The size of a transition time in bytes: 4 in version 1 blocks, 8 otherwise
+	BlockLength int    // The length of the data block following the header
+}
+
+// Transition is an instant, in seconds since the Unix epoch, at which the local time type changes.
+type Transition struct {
+	Time int64
+	Type int // The index of the local time type in effect from Time on
+}
+
+// LocalTimeType describes a local time: its UTC offset, whether it is daylight saving time,
+// its abbreviation and how the transitions to it were specified.
+type LocalTimeType struct {
+	Offset       int32 // The UTC offset in seconds
+	IsDST        bool
+	Abbreviation string
+	IsStd        bool // The transitions were specified in standard time rather than wall time
+	IsUT         bool // The transitions were specified in UT rather than local time
+}
+
+// LeapSecond is a leap second record: from Time on, Correction seconds have been inserted in total.
+type LeapSecond struct {
+	Time       int64
+	Correction int32
+}
+
+// File is a parsed TZif file.
+type File struct {
+	Version     int    // 1, 2, 3 or 4
+	V1Header    Header // The header of the version 1 data block
+	Header      Header // The header of the data block used: the version 2+ block if present
+	Transitions []Transition
+	Types       []LocalTimeType
+	LeapSeconds []LeapSecond
+	Footer      string // The POSIX TZ string describing times after the last transition (version 2+)
+}
+
+const headerLength = 44
+
+// Parse parses the content of a TZif file. For version 2 and later files, the 64-bit
+// data block and the footer are used; the version 1 data block is only validated.
+func Parse(data []byte) (*File, error) {
+	f := &File{}
+	v1, err := parseHeader(data, 4)
+	if err != nil {
+		return nil, err
+	}
+	f.Version = versionNumber(data[4])
+	if f.Version == 0 {
+		return nil, fmt.Errorf("tzif: unsupported version %q", data[4])
+	}
+	f.V1Header = v1
+	if len(data) < headerLength+v1.BlockLength {
+		return nil, fmt.Errorf("tzif: truncated version 1 data block")
+	}
+	if f.Version == 1 {
+		f.Header = v1
+		if err := f.parseBlock(data[headerLength:headerLength+v1.BlockLength], v1); err != nil {
+			return nil, err
+		}
+		return f, nil
+	}
+
+	rest := data[headerLength+v1.BlockLength:]
+	v2, err := parseHeader(rest, 8)
+	if err != nil {
+		return nil, err
+	}
+	if versionNumber(rest[4]) != f.Version {
+		return nil, fmt.Errorf("tzif: version 1 and version %d headers disagree", f.Version)
+	}
+	f.Header = v2
+	if len(rest) < headerLength+v2.BlockLength {
+		return nil, fmt.Errorf("tzif: truncated version %d data block", f.Version)
+	}
+	if err := f.parseBlock(rest[headerLength:headerLength+v2.BlockLength], v2); err != nil {
+		return nil, err
+	}
+
+	footer := rest[headerLength+v2.BlockLength:]
+	if len(footer) < 2 || footer[0] != '\n' {
+		return nil, fmt.Errorf("tzif: missing footer")
+	}
+	end := bytes.IndexByte(footer[1:], '\n')
+	if end < 0 {
+		return nil, fmt.Errorf("tzif: unterminated footer")
+	}
+	f.Footer = string(footer[1 : end+1])
+	return f, nil
+}
+
+// versionNumber returns the version of a header's version byte, or 0 if unknown.
+func versionNumber(b byte) int {
+	switch b {
+	case 0:
+		return 1
+	case '2', '3', '4':
+		return int(b - '0')
+	}
+	return 0
+}
+
+// parseHeader parses a header at the start of 'data' for a block with transition times of 'timeSize' bytes.
+func parseHeader(data []byte, timeSize int) (Header, error) {
+	if len(data) < headerLength {
+		return Header{}, fmt.Errorf("tzif: truncated header")
+	}
+	if string(data[:4]) != "TZif" {
+		return Header{}, fmt.Errorf("tzif: not a TZif file")
+	}
+	counts := make([]uint32, 6)
+	for i := range counts {
+		counts[i] = binary.BigEndian.Uint32(data[20+4*i:])
+	}
+	h := Header{
+		IsUTCCount: counts[0],
+		IsStdCount: counts[1],
+		LeapCount:  counts[2],
+		TimeCount:  counts[3],
+		TypeCount:  counts[4],
+		CharCount:  counts[5],
+		TimeSize:   timeSize,
+	}
+	if h.TypeCount == 0 {
+		return h, fmt.Errorf("tzif: no local time types")
+	}
+	if h.IsUTCCount != 0 && h.IsUTCCount != h.TypeCount {
+		return h, fmt.Errorf("tzif: %d UT/local indicators for %d types", h.IsUTCCount, h.TypeCount)
+	}
+	if h.IsStdCount != 0 && h.IsStdCount != h.TypeCount {
+		return h, fmt.Errorf("tzif: %d standard/wall indicators for %d types", h.IsStdCount, h.TypeCount)
+	}
+	// guard against counts that would overflow the block length
+	const maxCount = 1 << 24
+	for _, count := range counts {
+		if count > maxCount {
+			return h, fmt.Errorf("tzif: implausible count %d", count)
+		}
+	}
+	h.BlockLength = int(h.TimeCount)*timeSize + int(h.TimeCount) + int(h.TypeCount)*6 +
+		int(h.CharCount) + int(h.LeapCount)*(timeSize+4) + int(h.IsStdCount) + int(h.IsUTCCount)
+	return h, nil
+}
+
+// parseBlock parses a data block described by the header 'h' into the file.
+func (f *File) parseBlock(block []byte, h Header) error {
+	readTime := func(b []byte) int64 {
+		if h.TimeSize == 4 {
+			return int64(int32(binary.BigEndian.Uint32(b)))
+		}
+		return int64(binary.BigEndian.Uint64(b))
+	}
+
+	times := block[:int(h.TimeCount)*h.TimeSize]
+	block = block[len(times):]
+	indices := block[:h.TimeCount]
+	block = block[h.TimeCount:]
+	f.Transitions = make([]Transition, h.TimeCount)
+	for i := range f.Transitions {
+		f.Transitions[i] = Transition{Time: readTime(times[i*h.TimeSize:]), Type: int(indices[i])}
+		if f.Transitions[i].Type >= int(h.TypeCount) {
+			return fmt.Errorf("tzif: transition %d uses unknown type %d", i, f.Transitions[i].Type)
+		}
+		if i > 0 && f.Transitions[i].Time <= f.Transitions[i-1].Time {
+			return fmt.Errorf("tzif: transition times are not ascending at transition %d", i)
+		}
+	}
+
+	records := block[:h.TypeCount*6]
+	block = block[len(records):]
+	chars := block[:h.CharCount]
+	block = block[h.CharCount:]
+	f.Types = make([]LocalTimeType, h.TypeCount)
+	for i := range f.Types {
+		record := records[i*6:]
+		abbreviationIndex := int(record[5])
+		if abbreviationIndex >= len(chars) {
+			return fmt.Errorf("tzif: type %d has an abbreviation index out of range", i)
+		}
+		abbreviation := chars[abbreviationIndex:]
+		if end := bytes.IndexByte(abbreviation, 0); end >= 0 {
+			abbreviation = abbreviation[:end]
+		}
+		f.Types[i] = LocalTimeType{
+			Offset:       int32(binary.BigEndian.Uint32(record)),
+			IsDST:        record[4] != 0,
+			Abbreviation: string(abbreviation),
+		}
+	}
+
+	recordSize := h.TimeSize + 4
+	f.LeapSeconds = make([]LeapSecond, h.LeapCount)
+	for i := range f.LeapSeconds {
+		record := block[i*recordSize:]
+		f.LeapSeconds[i] = LeapSecond{
+			Time:       readTime(record),
+			Correction: int32(binary.BigEndian.Uint32(record[h.TimeSize:])),
+		}
+	}
+	block = block[int(h.LeapCount)*recordSize:]
+
+	for i := 0; i < int(h.IsStdCount); i++ {
+		f.Types[i].IsStd = block[i] != 0
+	}
+	block = block[h.IsStdCount:]
+	for i := 0; i < int(h.IsUTCCount); i++ {
+		f.Types[i].IsUT = block[i] != 0
+	}
+	return nil
+}
+
+// Encode returns the content of the TZif file 'f'. Files of version 2 and later get a
+// version 1 data block with the transitions and leap seconds that fit in 32 bits,
+// followed by the 64-bit data block and the footer.
+func Encode(f *File) []byte {
+	var b bytes.Buffer
+	version := byte(0)
+	if f.Version > 1 {
+		version = byte('0' + f.Version)
+	}
+	f.encodeBlock(&b, version, 4)
+	if f.Version > 1 {
+		f.encodeBlock(&b, version, 8)
+		b.WriteString("\n" + f.Footer + "\n")
+	}
+	return b.Bytes()
+}
+
+// encodeBlock writes a header and its data block with transition times of 'timeSize' bytes.
+func (f *File) encodeBlock(b *bytes.Buffer, version byte, timeSize int) {
+	fits := func(t int64) bool { return timeSize == 8 || t == int64(int32(t)) }
+	var transitions []Transition
+	for _, transition := range f.Transitions {
+		if fits(transition.Time) {
+			transitions = append(transitions, transition)
+		}
+	}
+	var leapSeconds []LeapSecond
+	for _, leap := range f.LeapSeconds {
+		if fits(leap.Time) {
+			leapSeconds = append(leapSeconds, leap)
+		}
+	}
+	var chars []byte
+	abbreviationIndex := map[string]int{}
+	indicators := 0
+	for _, t := range f.Types {
+		if _, ok := abbreviationIndex[t.Abbreviation]; !ok {
+			abbreviationIndex[t.Abbreviation] = len(chars)
+			chars = append(append(chars, t.Abbreviation...), 0)
+		}
+		if t.IsStd || t.IsUT {
+			indicators = len(f.Types)
+		}
+	}
+
+	b.WriteString("TZif")
+	b.WriteByte(version)
+	b.Write(make([]byte, 15))
+	for _, count := range []int{indicators, indicators, len(leapSeconds), len(transitions), len(f.Types), len(chars)} {
+		binary.Write(b, binary.BigEndian, uint32(count))
+	}
+	writeTime := func(t int64) {
+		if timeSize == 4 {
+			binary.Write(b, binary.BigEndian, int32(t))
+		} else {
+			binary.Write(b, binary.BigEndian, t)
+		}
+	}
+	for _, transition := range transitions {
+		writeTime(transition.Time)
+	}
+	for _, transition := range transitions {
+		b.WriteByte(byte(transition.Type))
+	}
+	for _, t := range f.Types {
+		binary.Write(b, binary.BigEndian, t.Offset)
+		b.Write([]byte{boolByte(t.IsDST), byte(abbreviationIndex[t.Abbreviation])})
+	}
+	b.Write(chars)
+	for _, leap := range leapSeconds {
+		writeTime(leap.Time)
+		binary.Write(b, binary.BigEndian, leap.Correction)
+	}
+	if indicators > 0 {
+		for _, t := range f.Types {
+			b.WriteByte(boolByte(t.IsStd))
+		}
+		for _, t := range f.Types {
+			b.WriteByte(boolByte(t.IsUT))
+		}
+	}
+}
+
+func boolByte(v bool) byte {
+	if v {
+		return 1
+	}
+	return 0
+}
+
+// TypeAt returns the local time type in effect at 'unix' seconds according to the
+// transitions. Before the first transition, the first type is used, as RFC 8536 specifies.
+// After the last transition the footer applies, which TypeAt does not evaluate.
+func (f *File) TypeAt(unix int64) LocalTimeType {
+	typeIndex := 0
+	for _, transition := range f.Transitions {
+		if transition.Time > unix {
+			break
+		}
+		typeIndex = transition.Type
+	}
+	return f.Types[typeIndex]
+}
@@ -0,0 +1,157 @@
+package tzif
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/kritibb/ktz/tzdata"
+)
+
+// readEmbeddedZone returns the TZif data of a zone from the snapshot embedded in ktz.
+func readEmbeddedZone(t *testing.T, zone string) []byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(tzdata.ZoneinfoZip), int64(len(tzdata.ZoneinfoZip)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := archive.Open(zone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+// buildV1 returns a version 1 TZif file with two types, one transition and one leap second.
+func buildV1(version byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("TZif")
+	b.WriteByte(version)
+	b.Write(make([]byte, 15))
+	for _, count := range []uint32{2, 2, 1, 1, 2, 8} { // isut, isstd, leap, time, type, char
+		binary.Write(&b, binary.BigEndian, count)
+	}
+	binary.Write(&b, binary.BigEndian, int32(-1000)) // transition time
+	b.WriteByte(1)                                   // its type
+	binary.Write(&b, binary.BigEndian, int32(3600))  // type 0: +01:00, standard, "AAA"
+	b.Write([]byte{0, 0})
+	binary.Write(&b, binary.BigEndian, int32(7200)) // type 1: +02:00, DST, "BBB"
+	b.Write([]byte{1, 4})
+	b.WriteString("AAA\x00BBB\x00")
+	binary.Write(&b, binary.BigEndian, int32(78796800)) // leap second
+	binary.Write(&b, binary.BigEndian, int32(1))
+	b.Write([]byte{1, 0}) // std/wall
+	b.Write([]byte{0, 1}) // UT/local
+	return b.Bytes()
+}
+
+func TestParseVersion1(t *testing.T) {
+	f, err := Parse(buildV1(0))
+	if err != nil {
+		t.Fatalf("Parse() returned error %v", err)
+	}
+	if f.Version != 1 || f.Footer != "" {
+		t.Fatalf("Parse() version %v footer %q, want 1 and no footer", f.Version, f.Footer)
+	}
+	wantTypes := []LocalTimeType{
+		{Offset: 3600, Abbreviation: "AAA", IsStd: true},
+		{Offset: 7200, IsDST: true, Abbreviation: "BBB", IsUT: true},
+	}
+	if len(f.Types) != 2 || f.Types[0] != wantTypes[0] || f.Types[1] != wantTypes[1] {
+		t.Fatalf("Parse() types = %+v, want %+v", f.Types, wantTypes)
+	}
+	if len(f.Transitions) != 1 || f.Transitions[0] != (Transition{Time: -1000, Type: 1}) {
+		t.Fatalf("Parse() transitions = %+v", f.Transitions)
+	}
+	if len(f.LeapSeconds) != 1 || f.LeapSeconds[0] != (LeapSecond{Time: 78796800, Correction: 1}) {
+		t.Fatalf("Parse() leap seconds = %+v", f.LeapSeconds)
+	}
+	if got := f.TypeAt(-2000).Abbreviation; got != "AAA" {
+		t.Fatalf("TypeAt(-2000) = %v, want AAA", got)
+	}
+	if got := f.TypeAt(0).Abbreviation; got != "BBB" {
+		t.Fatalf("TypeAt(0) = %v, want BBB", got)
+	}
+}
+
+func TestParseEmbedded(t *testing.T) {
+	f, err := Parse(readEmbeddedZone(t, "Asia/Kathmandu"))
+	if err != nil {
+		t.Fatalf("Parse(Asia/Kathmandu) returned error %v", err)
+	}
+	if f.Version < 2 || f.Footer != "<+0545>-5:45" {
+		t.Fatalf("Parse(Asia/Kathmandu) version %v footer %q, want 2+ and <+0545>-5:45", f.Version, f.Footer)
+	}
+	if got := f.TypeAt(1_000_000_000); got.Offset != 20700 || got.Abbreviation != "+0545" {
+		t.Fatalf("Parse(Asia/Kathmandu) type in 2001 = %+v, want +0545", got)
+	}
+
+	f, err = Parse(readEmbeddedZone(t, "America/New_York"))
+	if err != nil {
+		t.Fatalf("Parse(America/New_York) returned error %v", err)
+	}
+	if f.Footer != "EST5EDT,M3.2.0,M11.1.0" {
+		t.Fatalf("Parse(America/New_York) footer = %q", f.Footer)
+	}
+	if got := f.TypeAt(1_000_000_000); !got.IsDST || got.Abbreviation != "EDT" {
+		t.Fatalf("Parse(America/New_York) type in September 2001 = %+v, want EDT", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	valid := readEmbeddedZone(t, "Europe/Berlin")
+	badType := buildV1(0)
+	badType[44+4] = 5 // the transition's type index
+	tests := []struct {
+		name  string
+		given []byte
+	}{
+		{"empty", nil},
+		{"not TZif", []byte("This is not a timezone file at all, it is text.")},
+		{"unknown version", buildV1('9')},
+		{"truncated", valid[:len(valid)/2]},
+		{"no footer", buildV1('2')},
+		{"unknown type", badType},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := Parse(test.given); err == nil {
+				t.Fatalf("Parse() returned no error")
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	for _, data := range [][]byte{buildV1(0), readEmbeddedZone(t, "America/New_York"), readEmbeddedZone(t, "Asia/Kathmandu")} {
+		f, err := Parse(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := Parse(Encode(f))
+		if err != nil {
+			t.Fatalf("Parse(Encode()) returned error %v", err)
+		}
+		if again.Version != f.Version || again.Footer != f.Footer || len(again.Transitions) != len(f.Transitions) ||
+			len(again.Types) != len(f.Types) || len(again.LeapSeconds) != len(f.LeapSeconds) {
+			t.Fatalf("Parse(Encode()) = %+v, want %+v", again, f)
+		}
+		for i := range f.Transitions {
+			if again.Transitions[i] != f.Transitions[i] {
+				t.Fatalf("Parse(Encode()) transition %d = %+v, want %+v", i, again.Transitions[i], f.Transitions[i])
+			}
+		}
+		for i := range f.Types {
+			if again.Types[i] != f.Types[i] {
+				t.Fatalf("Parse(Encode()) type %d = %+v, want %+v", i, again.Types[i], f.Types[i])
+			}
+		}
+	}
+}